@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"skillmaster/pkg/compose"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var composeCmd = &cobra.Command{
+	Use:   "compose [file...]",
+	Short: "Build AGENTS.md / CLAUDE.md from installed packages",
+	Long: `Compose root instruction files from installed package files.
+
+Most assistants only read a single root instructions file. compose writes a
+managed block between skillmaster markers into each configured file, either
+inlining package files or @-importing them. Content outside the markers is
+left untouched.
+
+Files are configured under "compose" in skillmaster.json. Without
+configuration, AGENTS.md is composed with all packages inlined.
+
+Examples:
+  skillmaster compose                        # Compose all configured files
+  skillmaster compose CLAUDE.md --mode import`,
+	RunE: runCompose,
+}
+
+func init() {
+	composeCmd.Flags().String("mode", "", "Compose mode: import (@-references) or inline")
+}
+
+func runCompose(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Select the files to compose
+	files := configuredComposeFiles(m)
+	if len(args) > 0 {
+		files = nil
+		for _, arg := range args {
+			file := manifest.ComposeFile{Path: arg}
+			for _, configured := range configuredComposeFiles(m) {
+				if configured.Path == arg {
+					file = configured
+				}
+			}
+			files = append(files, file)
+		}
+	}
+
+	mode, _ := cmd.Flags().GetString("mode")
+	if mode != "" {
+		for i := range files {
+			files[i].Mode = mode
+		}
+	}
+
+	return composeFiles(cwd, m, files)
+}
+
+// configuredComposeFiles returns the compose files from the manifest,
+// falling back to the default AGENTS.md
+func configuredComposeFiles(m *manifest.Manifest) []manifest.ComposeFile {
+	if m.Compose != nil && len(m.Compose.Files) > 0 {
+		return append([]manifest.ComposeFile{}, m.Compose.Files...)
+	}
+	return []manifest.ComposeFile{compose.DefaultFile}
+}
+
+// composeOnInstall reports whether composed files should be refreshed
+// after packages are installed or removed
+func composeOnInstall(cmd *cobra.Command, m *manifest.Manifest) bool {
+	if cmd.Flags().Lookup("compose") != nil {
+		if flag, _ := cmd.Flags().GetBool("compose"); flag {
+			return true
+		}
+	}
+	return m.Compose != nil && m.Compose.OnInstall
+}
+
+// composeFiles writes the managed block of each compose file
+func composeFiles(cwd string, m *manifest.Manifest, files []manifest.ComposeFile) error {
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		entries, warnings, err := compose.Select(file, m, lock)
+		if err != nil {
+			return fmt.Errorf("failed to compose %s: %w", file.Path, err)
+		}
+		for _, warning := range warnings {
+			color.Yellow("⚠ %s: %s", file.Path, warning)
+		}
+
+		content, err := compose.Render(file, entries, cwd)
+		if err != nil {
+			return fmt.Errorf("failed to compose %s: %w", file.Path, err)
+		}
+
+		if err := compose.Write(file, content, cwd); err != nil {
+			return err
+		}
+
+		mode := file.Mode
+		if mode == "" {
+			mode = manifest.ComposeImport
+		}
+		color.Green("✓ Composed %s (%d file(s), %s)", file.Path, len(entries), mode)
+	}
+
+	return nil
+}
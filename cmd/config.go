@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"skillmaster/pkg/config"
 
@@ -56,6 +57,20 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	fmt.Println("─────────────────────────────────────────")
 	fmt.Printf("%-20s %s\n", "Install Directory:", cfg.InstallDir)
 
+	// Show assistant profiles
+	profileNames := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		profileNames = append(profileNames, name)
+	}
+	sort.Strings(profileNames)
+	for i, name := range profileNames {
+		label := ""
+		if i == 0 {
+			label = "Profiles:"
+		}
+		fmt.Printf("%-20s %-10s %s\n", label, name, cfg.Profiles[name].InstallDir)
+	}
+
 	// Show GitHub token status (masked)
 	if cfg.GitHub.Token != "" {
 		maskedToken := cfg.GitHub.Token[:min(4, len(cfg.GitHub.Token))] + "..." +
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/scaffold"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Scaffold a new package repository",
+	Long: `Create a directory with a new SkillMaster package: a skillmaster-package.json,
+a README and example files from one of the built-in templates.
+
+Templates:
+  language-guide      Style guide and prompts for a programming language (default)
+  framework-patterns  Patterns and feature prompts for a framework
+  code-review         Code review and security checklists
+  claude-skill        Claude skill with SKILL.md, reference docs and a helper script
+
+Examples:
+  skillmaster create go-style
+  skillmaster create react-patterns --template framework-patterns
+  skillmaster create repo-audit --template claude-skill --author your-username`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCreate,
+}
+
+func init() {
+	createCmd.Flags().StringP("template", "t", scaffold.DefaultTemplate(), "Template to start from")
+	createCmd.Flags().StringP("description", "d", "", "Package description (defaults to the template's)")
+	createCmd.Flags().StringP("author", "a", "", "Package author, typically your GitHub username")
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	name := args[0]
+	templateName, _ := cmd.Flags().GetString("template")
+	description, _ := cmd.Flags().GetString("description")
+	author, _ := cmd.Flags().GetString("author")
+
+	dir := filepath.Join(cwd, name)
+	files, err := scaffold.Create(dir, scaffold.Options{
+		Name:        name,
+		Description: description,
+		Author:      author,
+		Template:    templateName,
+	})
+	if err != nil {
+		return err
+	}
+
+	color.Green("✓ Created package %s from the %s template", name, templateName)
+	for _, file := range files {
+		fmt.Printf("  %s\n", filepath.Join(name, filepath.FromSlash(file)))
+	}
+
+	owner := author
+	if owner == "" {
+		owner = "<owner>"
+	}
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. Edit the files in %s/ and describe the package in skillmaster-package.json\n", name)
+	fmt.Printf("  2. Commit it: %s\n", color.CyanString("cd %s && git init && git add -A && git commit -m \"Initial commit\"", name))
+	fmt.Printf("  3. Push it to GitHub: %s\n", color.CyanString("gh repo create %s --public --source=. --push", name))
+	fmt.Printf("  4. Add the %s topic so it shows up in search: %s\n", github.PackageTopic, color.CyanString("gh repo edit --add-topic %s", github.PackageTopic))
+	fmt.Printf("  5. Tag a release: %s\n", color.CyanString("git tag v0.1.0 && git push --tags"))
+	color.Blue("ℹ Others can then install it with: skillmaster install %s/%s", owner, name)
+
+	return nil
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/render"
+	"skillmaster/pkg/semver"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info <owner/repo>",
+	Short: "Show details of a package",
+	Long: `Show the details of a package on GitHub before installing it: its
+description, stars, last update, license and default branch, the available
+versions (releases and tags), the metadata from skillmaster-package.json, the
+files a project would install with their sizes, and the README.
+
+Metadata, files and README are shown for the version install would pick,
+unless --ref selects another tag or branch.
+
+Examples:
+  skillmaster info acme/go-style
+  skillmaster info acme/go-style --ref v1.2.0
+  skillmaster info acme/go-style --no-readme
+  skillmaster info acme/go-style --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInfo,
+}
+
+func init() {
+	infoCmd.Flags().String("ref", "", "Tag or branch to show the metadata, files and README of")
+	infoCmd.Flags().Bool("no-readme", false, "Do not show the README")
+	infoCmd.Flags().Bool("json", false, "Print the details as JSON")
+}
+
+// infoReport is the output of the info command
+type infoReport struct {
+	Package       string                    `json:"package"`
+	URL           string                    `json:"url"`
+	Description   string                    `json:"description,omitempty"`
+	Stars         int                       `json:"stars"`
+	UpdatedAt     string                    `json:"updatedAt,omitempty"`
+	License       string                    `json:"license,omitempty"`
+	DefaultBranch string                    `json:"defaultBranch"`
+	Ref           string                    `json:"ref"` // version the metadata, files and README are from
+	Versions      []infoVersion             `json:"versions"`
+	Metadata      *manifest.PackageManifest `json:"metadata,omitempty"`
+	Files         []infoFile                `json:"files"`
+	Warnings      []string                  `json:"warnings,omitempty"`
+	Readme        string                    `json:"readme,omitempty"`
+	ReadmePath    string                    `json:"readmePath,omitempty"`
+
+	latest bool // Ref is the version install picks rather than --ref
+}
+
+// infoVersion is a tag of the package, with its release if it has one
+type infoVersion struct {
+	Tag         string `json:"tag"`
+	Release     bool   `json:"release"`
+	Prerelease  bool   `json:"prerelease,omitempty"`
+	PublishedAt string `json:"publishedAt,omitempty"`
+}
+
+// infoFile is a file a project would install
+type infoFile struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	owner, repo, err := github.ParseRepoURL(args[0])
+	if err != nil {
+		return err
+	}
+	ref, _ := cmd.Flags().GetString("ref")
+	noReadme, _ := cmd.Flags().GetBool("no-readme")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Show warning if no GitHub token
+	if cfg.GetGitHubToken() == "" && !jsonOutput {
+		color.Yellow("⚠ No GitHub token configured. API rate limits will be lower.")
+		color.Blue("ℹ Add a token to ~/.skillmaster/config.json for higher rate limits")
+		fmt.Println()
+	}
+
+	// Create GitHub client
+	githubClient := github.NewClient(cfg.GetGitHubToken())
+
+	report, err := fetchInfo(githubClient, owner, repo, ref, !noReadme)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal package details: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printInfo(report)
+	return nil
+}
+
+// fetchInfo collects the details of a package
+func fetchInfo(githubClient *github.Client, owner, repo, ref string, readme bool) (*infoReport, error) {
+	repoInfo, err := githubClient.GetRepository(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	report := &infoReport{
+		Package:       fmt.Sprintf("%s/%s", owner, repo),
+		URL:           fmt.Sprintf("https://github.com/%s/%s", owner, repo),
+		Description:   repoInfo.Description,
+		Stars:         repoInfo.Stars,
+		UpdatedAt:     repoInfo.UpdatedAt,
+		License:       repoInfo.License,
+		DefaultBranch: repoInfo.DefaultBranch,
+		Files:         []infoFile{},
+	}
+
+	// Versions
+	tags, err := githubClient.ListTags(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := githubClient.ListReleases(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	report.Versions = packageVersions(tags, releases)
+
+	// Pick the version install would: the latest release, else the
+	// latest tag, else the default branch
+	report.Ref = ref
+	report.latest = ref == ""
+	if report.Ref == "" {
+		for _, release := range releases {
+			if !release.Prerelease {
+				report.Ref = release.TagName
+				break
+			}
+		}
+	}
+	if report.Ref == "" && len(tags) > 0 {
+		report.Ref = tags[0]
+	}
+	if report.Ref == "" {
+		report.Ref = repoInfo.DefaultBranch
+	}
+
+	// Package metadata
+	data, err := githubClient.GetFile(owner, repo, report.Ref, manifest.PackageFileName)
+	if err == nil {
+		if report.Metadata, err = manifest.ParsePackage(data); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, github.ErrNotFound) {
+		return nil, err
+	}
+
+	// Files a project would install
+	files, err := githubClient.ListFiles(owner, repo, report.Ref)
+	if err != nil {
+		return nil, err
+	}
+	selected, warnings := installer.SelectFiles(report.Metadata, files)
+	for _, file := range selected {
+		report.Files = append(report.Files, infoFile{Path: file.Path, Size: file.Size})
+	}
+	report.Warnings = warnings
+
+	// README
+	if readme {
+		readmePath, content, err := githubClient.GetReadme(owner, repo, report.Ref)
+		if err == nil {
+			report.ReadmePath = readmePath
+			report.Readme = string(content)
+		} else if !errors.Is(err, github.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// packageVersions merges tags and releases, newest semantic version first.
+// Tags that are not semantic versions come last.
+func packageVersions(tags []string, releases []*github.Release) []infoVersion {
+	byTag := make(map[string]*github.Release)
+	for _, release := range releases {
+		byTag[release.TagName] = release
+	}
+
+	versions := []infoVersion{}
+	for _, tag := range tags {
+		version := infoVersion{Tag: tag}
+		if release, ok := byTag[tag]; ok {
+			version.Release = true
+			version.Prerelease = release.Prerelease
+			version.PublishedAt = release.PublishedAt
+		}
+		versions = append(versions, version)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		a, errA := semver.Parse(versions[i].Tag)
+		b, errB := semver.Parse(versions[j].Tag)
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		return a.Compare(b) > 0
+	})
+	return versions
+}
+
+// printInfo prints the details of a package
+func printInfo(report *infoReport) {
+	const shownVersions = 10
+
+	fmt.Printf("%s %s\n", color.GreenString(report.Package), color.YellowString("⭐ %d", report.Stars))
+	if report.Description != "" {
+		fmt.Printf("  %s\n", report.Description)
+	}
+	fmt.Println()
+	printInfoField("URL", report.URL)
+	printInfoField("Updated", report.UpdatedAt)
+	printInfoField("License", report.License)
+	printInfoField("Default branch", report.DefaultBranch)
+
+	// Versions
+	fmt.Println()
+	color.Cyan("Versions (%d)", len(report.Versions))
+	if len(report.Versions) == 0 {
+		fmt.Printf("  none; installs track %s\n", report.DefaultBranch)
+	}
+	for i, version := range report.Versions {
+		if i == shownVersions {
+			fmt.Printf("  ... and %d more\n", len(report.Versions)-shownVersions)
+			break
+		}
+		kind := "tag"
+		switch {
+		case version.Prerelease:
+			kind = "prerelease"
+		case version.Release:
+			kind = "release"
+		}
+		line := fmt.Sprintf("  %-20s %-11s %s", version.Tag, kind, version.PublishedAt)
+		if version.Tag == report.Ref {
+			marker := "  ← shown"
+			if report.latest {
+				marker = "  ← latest"
+			}
+			line = color.GreenString("%s", strings.TrimRight(line, " ")+marker)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+
+	// Package metadata
+	fmt.Println()
+	if pkg := report.Metadata; pkg != nil {
+		color.Cyan("Package (%s at %s)", manifest.PackageFileName, report.Ref)
+		printInfoField("Name", pkg.Name)
+		printInfoField("Version", pkg.Version)
+		printInfoField("Author", pkg.Author)
+		printInfoField("License", pkg.License)
+		printInfoField("Keywords", strings.Join(pkg.Keywords, ", "))
+		if pkg.Description != "" && pkg.Description != report.Description {
+			printInfoField("Description", pkg.Description)
+		}
+		var deps []string
+		for name, constraint := range pkg.Dependencies {
+			deps = append(deps, fmt.Sprintf("%s %s", name, constraint))
+		}
+		sort.Strings(deps)
+		printInfoField("Dependencies", strings.Join(deps, ", "))
+	} else {
+		color.Cyan("Package")
+		fmt.Printf("  No %s at %s; every markdown file is installed\n", manifest.PackageFileName, report.Ref)
+	}
+
+	// Files
+	total := 0
+	for _, file := range report.Files {
+		total += file.Size
+	}
+	fmt.Println()
+	color.Cyan("Files at %s (%d, %s)", report.Ref, len(report.Files), installer.FormatSize(total))
+	for _, file := range report.Files {
+		fmt.Printf("  %-8s %s\n", installer.FormatSize(file.Size), file.Path)
+	}
+	for _, warning := range report.Warnings {
+		color.Yellow("  ⚠ %s", warning)
+	}
+
+	// README
+	if report.Readme != "" {
+		fmt.Println()
+		color.Cyan("%s", report.ReadmePath)
+		fmt.Println(strings.Repeat("─", 80))
+		fmt.Print(render.Markdown([]byte(report.Readme)))
+		fmt.Println(strings.Repeat("─", 80))
+	}
+
+	fmt.Println()
+	fmt.Printf("%s %s\n", color.BlueString("Install:"), color.CyanString("skillmaster install %s", report.Package))
+}
+
+// printInfoField prints a labeled value, skipping empty values
+func printInfoField(label, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Printf("  %-15s %s\n", label+":", value)
+}
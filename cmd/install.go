@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"skillmaster/pkg/config"
 	"skillmaster/pkg/github"
 	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
 	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/resolver"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -21,17 +24,33 @@ var installCmd = &cobra.Command{
 
 When run without arguments, installs all packages listed in skillmaster.json.
 When run with a package name, installs that specific package.
-	
+
+When two packages would write the same file, the installation stops unless
+a conflict policy is set with --conflicts or config.conflicts in
+skillmaster.json (fail, first-wins, last-wins, interactive or rename).
+
 Examples:
   skillmaster install                            # Install all packages from manifest
   skillmaster install anthropic/claude-best-practices  # Install specific package
-  skillmaster install --force                    # Force reinstall all packages`,
+  skillmaster install --force                    # Force reinstall all packages
+  skillmaster install --profile claude,cursor    # Install into several assistants' directories
+  skillmaster install --conflicts rename         # Keep both files when packages collide`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runInstall,
 }
 
 func init() {
-	installCmd.Flags().BoolP("force", "f", false, "Force reinstall even if package is already installed")
+	installCmd.Flags().BoolP("force", "f", false, "Force reinstall even if package is already installed, overwriting local changes")
+	installCmd.Flags().StringSliceP("profile", "p", nil, "Install into the directories of the given assistant profiles (e.g. claude,cursor)")
+	installCmd.Flags().Bool("compose", false, "Compose AGENTS.md / CLAUDE.md after installing")
+	installCmd.Flags().String("conflicts", "", "Policy for files written by several packages: fail, first-wins, last-wins, interactive or rename")
+}
+
+// installOptions holds the settings shared by every package of an install run
+type installOptions struct {
+	Targets   []installTarget
+	Force     bool
+	Conflicts string
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
@@ -50,17 +69,52 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	// Get force flag
 	force, _ := cmd.Flags().GetBool("force")
 
-	// If no arguments, install all packages from manifest
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Resolve the directories to install into
+	profiles, _ := cmd.Flags().GetStringSlice("profile")
+	targets, err := resolveInstallTargets(m, cfg, profiles)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the conflict policy, the flag taking precedence over the manifest
+	conflicts, _ := cmd.Flags().GetString("conflicts")
+	if conflicts == "" {
+		conflicts = m.Config.Conflicts
+	}
+	if conflicts != "" && !installer.ValidConflictPolicy(conflicts) {
+		return fmt.Errorf("unknown conflict policy: %s (expected fail, first-wins, last-wins, interactive or rename)", conflicts)
+	}
+
+	opts := installOptions{Targets: targets, Force: force, Conflicts: conflicts}
+
+	// If no arguments, install all packages from manifest,
+	// otherwise install specific package
 	if len(args) == 0 {
-		return installAll(m, cwd, force)
+		err = installAll(m, cfg, cwd, opts)
+	} else {
+		err = installPackage(args[0], m, cfg, cwd, opts)
+	}
+	if err != nil {
+		return err
 	}
 
-	// Otherwise, install specific package
-	return installPackage(args[0], m, cwd, force)
+	// Refresh composed instruction files
+	if composeOnInstall(cmd, m) {
+		fmt.Println()
+		return composeFiles(cwd, m, configuredComposeFiles(m))
+	}
+
+	return nil
 }
 
 // installAll installs all packages from the manifest
-func installAll(m *manifest.Manifest, cwd string, force bool) error {
+func installAll(m *manifest.Manifest, cfg *config.GlobalConfig, cwd string, opts installOptions) error {
 	if len(m.Dependencies) == 0 {
 		color.Yellow("No packages to install")
 		fmt.Println()
@@ -69,10 +123,10 @@ func installAll(m *manifest.Manifest, cwd string, force bool) error {
 		return nil
 	}
 
-	// Load global config
-	cfg, err := config.Load()
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	// Show warning if no GitHub token
@@ -88,18 +142,24 @@ func installAll(m *manifest.Manifest, cwd string, force bool) error {
 	// Create installer
 	inst := installer.New(githubClient)
 
-	// Install directory (absolute path)
-	installDir := filepath.Join(cwd, m.Config.InstallDir)
+	// Resolve the packages of the manifest and their dependencies
+	graph, err := resolveDependencies(githubClient, m, lock)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println()
 	color.Cyan("Installing packages...")
 	fmt.Println()
 
-	installedCount := 0
 	skippedCount := 0
+	failedCount := 0
+
+	// Fetch each package that still needs installing
+	var jobs []*installJob
+	for _, packageName := range graph.Order {
+		version := graph.Node(packageName).Version
 
-	// Install each package
-	for packageName := range m.Dependencies {
 		// Parse package name
 		owner, repo, err := github.ParseRepoURL(packageName)
 		if err != nil {
@@ -107,28 +167,98 @@ func installAll(m *manifest.Manifest, cwd string, force bool) error {
 			continue
 		}
 
-		// Check if already installed (unless force flag is set)
-		fileCount, err := installer.CountInstalledFiles(installDir, owner, repo)
-		if !force && err == nil && fileCount > 0 {
-			color.Green("✓ %s (already installed, %d files)", packageName, fileCount)
+		// Linked packages are synced from their local directory
+		locked := lock.Package(packageName)
+		if locked != nil && locked.Link != "" {
+			fmt.Printf("→ Syncing linked %s from %s...\n", color.CyanString(packageName), locked.Link)
+			job, err := linkedJob(packageName, version, locked.Link, m, opts.Targets)
+			if err != nil {
+				color.Red("✗ Failed to install %s: %v", packageName, err)
+				failedCount++
+				continue
+			}
+			jobs = append(jobs, job)
+			continue
+		}
+
+		// Check which targets still need the package (unless force flag is set
+		// or a different version was resolved)
+		update := locked != nil && locked.Version != version
+		var pending []installTarget
+		reinstall := false
+		for _, target := range opts.Targets {
+			fileCount := installedFileCount(cwd, lock, packageName, target)
+			if fileCount > 0 {
+				if !opts.Force && !update {
+					color.Green("✓ %s → %s (already installed, %d files)", packageName, target.Label(), fileCount)
+					continue
+				}
+				reinstall = true
+			}
+			pending = append(pending, target)
+		}
+		if len(pending) == 0 {
 			skippedCount++
 			continue
 		}
 
-		// Install package
-		if force && fileCount > 0 {
+		// Fetch package
+		switch {
+		case update:
+			fmt.Printf("→ Updating %s from %s to %s...\n", color.CyanString(packageName), locked.Version, version)
+		case reinstall:
 			fmt.Printf("→ Reinstalling %s...\n", color.CyanString(packageName))
-		} else {
+		default:
 			fmt.Printf("→ Installing %s...\n", color.CyanString(packageName))
 		}
-		fileCount, err = inst.InstallPackage(owner, repo, installDir)
+		pkg, err := inst.FetchPackageAt(owner, repo, version)
 		if err != nil {
 			color.Red("✗ Failed to install %s: %v", packageName, err)
+			failedCount++
 			continue
 		}
+		pkg.Options = m.GetDependencyConfig(packageName)
+		printPackageWarnings(packageName, pkg)
 
-		color.Green("✓ %s (%d files)", packageName, fileCount)
-		installedCount++
+		jobs = append(jobs, &installJob{Name: packageName, Version: version, Package: pkg, Targets: pending})
+	}
+
+	// Write the packages once conflicts between them are resolved
+	plans, err := writeJobs(jobs, m, lock, cwd, opts)
+	if err != nil {
+		return err
+	}
+
+	installedCount := 0
+	for _, job := range jobs {
+		failed := false
+		for _, plan := range plans {
+			if plan.Job != job {
+				continue
+			}
+			if plan.Err != nil {
+				color.Red("✗ Failed to install %s → %s: %v", job.Name, plan.Target.Label(), plan.Err)
+				failed = true
+				continue
+			}
+			color.Green("✓ %s → %s (%d files)", job.Name, plan.Target.Label(), plan.Count)
+		}
+		if failed {
+			failedCount++
+		} else {
+			installedCount++
+		}
+	}
+
+	// Record the dependency graph and drop dependencies no longer required
+	recordGraph(lock, graph)
+	if err := removeOrphans(m, lock, cwd); err != nil {
+		return err
+	}
+
+	// Save lock file
+	if err := lock.Save(cwd); err != nil {
+		return err
 	}
 
 	// Summary
@@ -139,22 +269,25 @@ func installAll(m *manifest.Manifest, cwd string, force bool) error {
 	if skippedCount > 0 {
 		color.Blue("ℹ Skipped %d already installed package(s)", skippedCount)
 	}
+	if failedCount > 0 {
+		color.Red("✗ Failed to install %d package(s)", failedCount)
+	}
 
 	return nil
 }
 
 // installPackage installs a specific package
-func installPackage(repoURL string, m *manifest.Manifest, cwd string, force bool) error {
+func installPackage(repoURL string, m *manifest.Manifest, cfg *config.GlobalConfig, cwd string, opts installOptions) error {
 	// Parse repository URL
 	owner, repo, err := github.ParseRepoURL(repoURL)
 	if err != nil {
 		return err
 	}
 
-	// Load global config
-	cfg, err := config.Load()
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	// Show warning if no GitHub token
@@ -167,13 +300,13 @@ func installPackage(repoURL string, m *manifest.Manifest, cwd string, force bool
 	// Create GitHub client
 	githubClient := github.NewClient(cfg.GetGitHubToken())
 
-	// Install directory (absolute path)
-	installDir := filepath.Join(cwd, m.Config.InstallDir)
-
-	// Check if already installed (unless force flag is set)
+	// Check if already installed in any target (unless force flag is set)
 	packageName := fmt.Sprintf("%s/%s", owner, repo)
-	existingFileCount, err := installer.CountInstalledFiles(installDir, owner, repo)
-	if !force && err == nil && existingFileCount > 0 {
+	existingFileCount := 0
+	for _, target := range opts.Targets {
+		existingFileCount += installedFileCount(cwd, lock, packageName, target)
+	}
+	if !opts.Force && existingFileCount > 0 {
 		color.Yellow("⚠ Package %s is already installed (%d files)", packageName, existingFileCount)
 		fmt.Print("Reinstall? (y/N): ")
 		var response string
@@ -184,6 +317,10 @@ func installPackage(repoURL string, m *manifest.Manifest, cwd string, force bool
 		}
 	}
 
+	if locked := lock.Package(packageName); locked != nil && locked.Link != "" {
+		color.Yellow("⚠ %s is linked to %s; installing it from GitHub unlinks it", packageName, locked.Link)
+	}
+
 	// Get latest version
 	color.Blue("→ Fetching repository information...")
 	version, err := githubClient.GetLatestVersion(owner, repo)
@@ -191,31 +328,201 @@ func installPackage(repoURL string, m *manifest.Manifest, cwd string, force bool
 		return fmt.Errorf("failed to get repository version: %w", err)
 	}
 
+	// Add to manifest dependencies first so conflicts are ranked in manifest
+	// order and the package is resolved with the others
+	m.AddDependency(packageName, version)
+
+	// Resolve the package's dependencies
+	graph, err := resolveDependencies(githubClient, m, lock)
+	if err != nil {
+		return err
+	}
+	version = graph.Node(packageName).Version
+
 	// Create installer
 	inst := installer.New(githubClient)
 
 	// Install package
-	if force || existingFileCount > 0 {
-		color.Blue("→ Reinstalling markdown files...")
+	if opts.Force || existingFileCount > 0 {
+		color.Blue("→ Reinstalling package files...")
 	} else {
-		color.Blue("→ Downloading markdown files...")
+		color.Blue("→ Downloading package files...")
 	}
-	fileCount, err := inst.InstallPackage(owner, repo, installDir)
+	pkg, err := inst.FetchPackageAt(owner, repo, version)
 	if err != nil {
 		return fmt.Errorf("installation failed: %w", err)
 	}
+	pkg.Options = m.GetDependencyConfig(packageName)
+	printPackageWarnings(packageName, pkg)
 
-	// Add to manifest dependencies
-	m.AddDependency(packageName, version)
+	job := &installJob{Name: packageName, Version: version, Package: pkg, Targets: opts.Targets}
+	jobs := []*installJob{job}
+
+	// Install the dependencies that are missing or resolved to another version
+	depJobs, err := dependencyJobs(inst, graph, packageName, m, lock, cwd, opts)
+	if err != nil {
+		return err
+	}
+	jobs = append(jobs, depJobs...)
+
+	plans, err := writeJobs(jobs, m, lock, cwd, opts)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if plan.Err != nil {
+			return fmt.Errorf("installation into %s failed: %w", plan.Target.Label(), plan.Err)
+		}
+	}
+
+	// Record the dependency graph and drop dependencies no longer required
+	recordGraph(lock, graph)
+	if err := removeOrphans(m, lock, cwd); err != nil {
+		return err
+	}
 
 	// Save manifest
 	if err := m.Save(cwd); err != nil {
 		return fmt.Errorf("failed to update manifest: %w", err)
 	}
 
+	// Save lock file
+	if err := lock.Save(cwd); err != nil {
+		return err
+	}
+
 	// Success message
 	color.Green("✓ Successfully installed %s@%s", packageName, version)
-	color.Blue("ℹ Installed %d markdown file(s) to %s/%s-%s/", fileCount, m.Config.InstallDir, owner, repo)
+	for _, plan := range plans {
+		if plan.Job != job {
+			continue
+		}
+		target := plan.Target
+		if (target.Target == "" || target.Target == installer.TargetDefault) && pkg.Options.Strategy == manifest.StrategySeparate {
+			color.Blue("ℹ Installed %d file(s) to %s/%s/", plan.Count, target.InstallDir, pkg.Namespace())
+		} else if target.Target == "" || target.Target == installer.TargetDefault {
+			color.Blue("ℹ Installed %d file(s) to %s/ (%s)", plan.Count, target.InstallDir, pkg.Options.Strategy)
+		} else {
+			color.Blue("ℹ Installed %d file(s) to %s/ as %s", plan.Count, target.InstallDir, target.Target)
+		}
+	}
+	for _, depJob := range depJobs {
+		color.Blue("ℹ Installed dependency %s@%s", depJob.Name, depJob.Version)
+	}
 
 	return nil
 }
+
+// dependencyJobs fetches the dependencies of a package, direct and
+// indirect, that are not installed in every target at their resolved version
+func dependencyJobs(inst *installer.Installer, graph *resolver.Graph, packageName string, m *manifest.Manifest, lock *lockfile.LockFile, cwd string, opts installOptions) ([]*installJob, error) {
+	var jobs []*installJob
+	seen := map[string]bool{packageName: true}
+	queue := []string{packageName}
+	for len(queue) > 0 {
+		node := graph.Node(queue[0])
+		queue = queue[1:]
+
+		var deps []string
+		for dep := range node.Dependencies {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			queue = append(queue, dep)
+
+			version := graph.Node(dep).Version
+			locked := lock.Package(dep)
+			var pending []installTarget
+			for _, target := range opts.Targets {
+				if opts.Force || locked == nil || locked.Version != version || installedFileCount(cwd, lock, dep, target) == 0 {
+					pending = append(pending, target)
+				}
+			}
+			if len(pending) == 0 {
+				continue
+			}
+
+			if locked != nil && locked.Link != "" {
+				job, err := linkedJob(dep, version, locked.Link, m, pending)
+				if err != nil {
+					return nil, fmt.Errorf("failed to install dependency %s: %w", dep, err)
+				}
+				jobs = append(jobs, job)
+				continue
+			}
+
+			owner, repo, err := github.ParseRepoURL(dep)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dependency of %s: %w", node.Name, err)
+			}
+			color.Blue("→ Downloading dependency %s@%s...", dep, version)
+			pkg, err := inst.FetchPackageAt(owner, repo, version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to install dependency %s: %w", dep, err)
+			}
+			pkg.Options = m.GetDependencyConfig(dep)
+			printPackageWarnings(dep, pkg)
+			jobs = append(jobs, &installJob{Name: dep, Version: version, Package: pkg, Targets: pending})
+		}
+	}
+	return jobs, nil
+}
+
+// printPackageWarnings reports the declared assets of a package that were skipped
+func printPackageWarnings(packageName string, pkg *installer.Package) {
+	for _, warning := range pkg.Warnings {
+		color.Yellow("⚠ %s: %s", packageName, warning)
+	}
+}
+
+// removeLockedFiles deletes installed files, removing only the package's
+// section from shared files
+func removeLockedFiles(cwd string, files []lockfile.LockedFile) error {
+	var paths []string
+	for _, file := range files {
+		if file.Section != "" {
+			if err := installer.RemoveSection(filepath.Join(cwd, filepath.FromSlash(file.Path)), file.Section); err != nil {
+				return err
+			}
+			continue
+		}
+		paths = append(paths, file.Path)
+	}
+	return installer.RemoveFiles(cwd, paths)
+}
+
+// installedFileCount returns how many files of a package are present in a target.
+// Files recorded in the lock file are counted first; installations that predate
+// the lock file fall back to counting the owner-repo directory.
+func installedFileCount(cwd string, lock *lockfile.LockFile, packageName string, target installTarget) int {
+	if locked := lock.Package(packageName); locked != nil {
+		if files := locked.FilesFor(target.Profile); len(files) > 0 {
+			count := 0
+			for _, file := range files {
+				if _, err := os.Stat(filepath.Join(cwd, filepath.FromSlash(file.Path))); err == nil {
+					count++
+				}
+			}
+			return count
+		}
+	}
+
+	if target.Target != "" && target.Target != installer.TargetDefault {
+		return 0
+	}
+
+	owner, repo, err := github.ParseRepoURL(packageName)
+	if err != nil {
+		return 0
+	}
+	count, err := installer.CountInstalledFiles(target.Path(cwd), owner, repo)
+	if err != nil {
+		return 0
+	}
+	return count
+}
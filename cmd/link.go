@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/git"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/link"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/publish"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link [name]",
+	Short: "Use a local package directory in place of an installed package",
+	Long: `Develop a package against a project without publishing it.
+
+Run without arguments in a package directory to register it globally under
+the name in its skillmaster-package.json. The GitHub repository it replaces is
+taken from the origin remote unless --repo is given.
+
+Run with the name (or owner/repo) of a registered package in a project that
+depends on it to install the local files in place of the pinned version.
+They go through the same targets, overrides and patches as a normal install.
+skillmaster install syncs linked packages from disk again, and
+skillmaster unlink restores the pinned version.
+
+Examples:
+  cd ~/src/go-style && skillmaster link         # Register the package
+  cd ~/src/app && skillmaster link go-style      # Use it in a project
+  skillmaster link --list                        # Show registered packages`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLink,
+}
+
+func init() {
+	linkCmd.Flags().String("repo", "", "GitHub repository (owner/repo) the package replaces; defaults to the origin remote's")
+	linkCmd.Flags().Bool("list", false, "List the registered package directories")
+	linkCmd.Flags().StringSliceP("profile", "p", nil, "Install into the directories of the given assistant profiles (e.g. claude,cursor)")
+	linkCmd.Flags().BoolP("force", "f", false, "Overwrite local changes to the installed files")
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	registry, err := link.Load()
+	if err != nil {
+		return err
+	}
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		printLinks(registry)
+		return nil
+	}
+	if len(args) == 0 {
+		repo, _ := cmd.Flags().GetString("repo")
+		return registerLink(registry, cwd, repo)
+	}
+	return linkPackage(cmd, registry, cwd, args[0])
+}
+
+// registerLink registers the package in dir for linking
+func registerLink(registry *link.Registry, dir, repo string) error {
+	pkg, err := manifest.LoadPackage(dir)
+	if err != nil {
+		return fmt.Errorf("%w; run skillmaster link in a package directory, or skillmaster link <name> in a project", err)
+	}
+
+	if repo == "" {
+		repo = originRepo(dir)
+	}
+	if repo != "" {
+		owner, name, err := github.ParseRepoURL(repo)
+		if err != nil {
+			return err
+		}
+		repo = owner + "/" + name
+	}
+
+	registry.Add(&link.Link{Name: pkg.Name, Repo: repo, Dir: dir})
+	if err := registry.Save(); err != nil {
+		return err
+	}
+
+	if repo != "" {
+		color.Green("✓ Registered %s (%s) → %s", pkg.Name, repo, dir)
+	} else {
+		color.Green("✓ Registered %s → %s", pkg.Name, dir)
+		color.Yellow("⚠ No GitHub repository found; rerun with --repo owner/repo to link it into projects")
+	}
+	color.Blue("ℹ Use it in a project with: skillmaster link %s", pkg.Name)
+	return nil
+}
+
+// originRepo returns the owner/repo of the origin remote of dir, or ""
+func originRepo(dir string) string {
+	repo, err := git.Open(dir)
+	if err != nil {
+		return ""
+	}
+	remoteURL, err := repo.RemoteURL("origin")
+	if err != nil {
+		return ""
+	}
+	name, err := publish.RepoFromRemote(remoteURL)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// printLinks lists the registered package directories
+func printLinks(registry *link.Registry) {
+	links := registry.List()
+	if len(links) == 0 {
+		color.Yellow("No linked packages")
+		fmt.Println()
+		fmt.Println("Register a package directory with:")
+		fmt.Printf("  %s\n", color.CyanString("skillmaster link"))
+		return
+	}
+
+	fmt.Println()
+	color.Cyan("Linked Packages")
+	fmt.Println(strings.Repeat("─", 70))
+	for _, l := range links {
+		repo := l.Repo
+		if repo == "" {
+			repo = "-"
+		}
+		fmt.Printf("%-25s %-30s %s\n", l.Name, repo, l.Dir)
+	}
+	fmt.Println(strings.Repeat("─", 70))
+}
+
+// linkPackage installs a registered package directory in place of the
+// project's dependency
+func linkPackage(cmd *cobra.Command, registry *link.Registry, cwd, query string) error {
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	l := registry.Find(query)
+	if l == nil {
+		return fmt.Errorf("no linked package %s; run skillmaster link in the package directory first", query)
+	}
+	if l.Repo == "" {
+		return fmt.Errorf("%s has no GitHub repository; run skillmaster link --repo owner/repo in %s", l.Name, l.Dir)
+	}
+	if _, err := os.Stat(l.Dir); err != nil {
+		return fmt.Errorf("linked directory of %s is missing: %s", l.Name, l.Dir)
+	}
+
+	packageName := dependencyName(m, lock, l.Repo)
+	if packageName == "" {
+		return fmt.Errorf("%s is not a dependency of this project; install it first with skillmaster install %s", l.Repo, l.Repo)
+	}
+
+	// Keep the pinned version for unlink
+	version := m.Dependencies[packageName]
+	locked := lock.Package(packageName)
+	if locked != nil {
+		version = locked.Version
+	}
+
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Link into the directories given, or those the package is installed in
+	profiles, _ := cmd.Flags().GetStringSlice("profile")
+	var targets []installTarget
+	if len(profiles) > 0 || locked == nil {
+		targets, err = resolveInstallTargets(m, cfg, profiles)
+	} else {
+		targets, err = lockedTargets(m, cfg, locked)
+	}
+	if err != nil {
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	opts := installOptions{Targets: targets, Force: force, Conflicts: m.Config.Conflicts}
+
+	color.Blue("→ Linking %s to %s...", packageName, l.Dir)
+	job, err := linkedJob(packageName, version, l.Dir, m, targets)
+	if err != nil {
+		return err
+	}
+	plans, err := writeJobs([]*installJob{job}, m, lock, cwd, opts)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if plan.Err != nil {
+			return fmt.Errorf("linking into %s failed: %w", plan.Target.Label(), plan.Err)
+		}
+	}
+
+	// Save lock file
+	if err := lock.Save(cwd); err != nil {
+		return err
+	}
+
+	color.Green("✓ Linked %s → %s", packageName, l.Dir)
+	for _, plan := range plans {
+		color.Blue("ℹ Installed %d file(s) to %s", plan.Count, plan.Target.Label())
+	}
+	color.Blue("ℹ Run skillmaster install to sync changes, skillmaster unlink %s to restore %s", packageName, version)
+
+	// Refresh composed instruction files
+	if composeOnInstall(cmd, m) {
+		fmt.Println()
+		return composeFiles(cwd, m, configuredComposeFiles(m))
+	}
+	return nil
+}
+
+// dependencyName returns the project's dependency or installed package
+// matching owner/repo, ignoring case, or ""
+func dependencyName(m *manifest.Manifest, lock *lockfile.LockFile, repo string) string {
+	for name := range m.Dependencies {
+		if strings.EqualFold(name, repo) {
+			return name
+		}
+	}
+	for name := range lock.Packages {
+		if strings.EqualFold(name, repo) {
+			return name
+		}
+	}
+	return ""
+}
+
+// linkedJob reads a linked package from its local directory
+func linkedJob(packageName, version, dir string, m *manifest.Manifest, targets []installTarget) (*installJob, error) {
+	owner, repo, err := github.ParseRepoURL(packageName)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := installer.LoadLocalPackage(dir, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read linked package %s: %w", packageName, err)
+	}
+	pkg.Options = m.GetDependencyConfig(packageName)
+	printPackageWarnings(packageName, pkg)
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	return &installJob{Name: packageName, Version: version, Package: pkg, Targets: targets, Link: absDir}, nil
+}
+
+// lockedTargets returns the install targets a locked package has files in
+func lockedTargets(m *manifest.Manifest, cfg *config.GlobalConfig, locked *lockfile.LockedPackage) ([]installTarget, error) {
+	var profiles []string
+	seen := make(map[string]bool)
+	defaultDir := len(locked.Files) == 0
+	for _, file := range locked.Files {
+		if file.Profile == "" {
+			defaultDir = true
+		} else if !seen[file.Profile] {
+			seen[file.Profile] = true
+			profiles = append(profiles, file.Profile)
+		}
+	}
+
+	var targets []installTarget
+	if defaultDir {
+		targets = append(targets, installTarget{InstallDir: m.Config.InstallDir})
+	}
+	if len(profiles) > 0 {
+		profileTargets, err := resolveInstallTargets(m, cfg, profiles)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, profileTargets...)
+	}
+	return targets, nil
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/lint"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/validate"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [dir]",
+	Short: "Check the quality of instruction files",
+	Long: `Lint the markdown files of a package, or the files installed in a project.
+
+In a directory with a skillmaster-package.json, the files the package would
+install are linted with the rules configured in its "lint" section. Otherwise,
+or with --installed, the files installed from skillmaster.lock are linted with
+the rules configured in the "lint" section of skillmaster.json.
+
+Rules:
+  max-size            Files must stay below a size and an estimated token count
+  required-headings   Files must contain the configured headings
+  no-absolute-paths   Files must not contain local paths such as /Users/me
+  no-todo             Files must not contain TODO or FIXME markers outside code
+  duplicate-headings  A heading used in several files must have the same content
+
+lint exits with a non-zero status when a rule set to error fails, or any rule
+with --strict.
+
+Examples:
+  skillmaster lint
+  skillmaster lint ./go-style --strict
+  skillmaster lint --installed --json
+  skillmaster lint --rules`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+func init() {
+	lintCmd.Flags().Bool("installed", false, "Lint the files installed in the project instead of a package")
+	lintCmd.Flags().Bool("strict", false, "Fail on warnings as well as errors")
+	lintCmd.Flags().Bool("json", false, "Print the findings as JSON")
+	lintCmd.Flags().Bool("rules", false, "List the rules and their configured severity")
+}
+
+// lintReport is the result of a lint run
+type lintReport struct {
+	Mode     string         `json:"mode"` // package or installed
+	Files    int            `json:"files"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	installed, _ := cmd.Flags().GetBool("installed")
+	if !installed {
+		if _, err := os.Stat(filepath.Join(dir, manifest.PackageFileName)); os.IsNotExist(err) {
+			installed = true
+		}
+	}
+
+	// Collect the files and the rule configuration
+	var config *manifest.LintConfig
+	var files []lint.File
+	var err error
+	mode := "package"
+	if installed {
+		mode = "installed"
+		config, files, err = installedLintFiles(dir)
+	} else {
+		config, files, err = packageLintFiles(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.New(config)
+	if err != nil {
+		return err
+	}
+
+	listRules, _ := cmd.Flags().GetBool("rules")
+	if listRules {
+		for _, rule := range linter.Rules() {
+			fmt.Printf("%-20s %-8s %s\n", rule.Name(), linter.Severity(rule.Name()), rule.Description())
+		}
+		return nil
+	}
+
+	report := lintReport{Mode: mode, Files: len(files), Findings: linter.Run(files)}
+
+	strict, _ := cmd.Flags().GetBool("strict")
+	errorCount, warningCount := 0, 0
+	for _, finding := range report.Findings {
+		if finding.Severity == lint.SeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+	failed := errorCount > 0 || (strict && warningCount > 0)
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printLintReport(report, dir, errorCount, warningCount, failed)
+	}
+
+	if failed {
+		// The report already lists the findings
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("lint failed")
+	}
+	return nil
+}
+
+// packageLintFiles returns the lint configuration of the package in dir
+// and the markdown files it would install
+func packageLintFiles(dir string) (*manifest.LintConfig, []lint.File, error) {
+	pkgManifest, err := manifest.LoadPackage(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w; run skillmaster validate for details", err)
+	}
+
+	report, err := validate.Run(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []lint.File
+	for _, filePath := range report.Files {
+		if !isInstructionFile(filePath) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(filePath)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		files = append(files, lint.File{Path: filePath, Content: content})
+	}
+	return pkgManifest.Lint, files, nil
+}
+
+// installedLintFiles returns the lint configuration of the project in dir
+// and the markdown files installed from skillmaster.lock. Shared files that
+// packages only own a section of are skipped.
+func installedLintFiles(dir string) (*manifest.LintConfig, []lint.File, error) {
+	m, err := manifest.Load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	lock, err := lockfile.Load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(lock.Packages))
+	for name := range lock.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var files []lint.File
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, file := range lock.Packages[name].Files {
+			if file.Section != "" || seen[file.Path] || !isInstructionFile(file.Path) {
+				continue
+			}
+			seen[file.Path] = true
+			content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue // reported by verify
+				}
+				return nil, nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+			files = append(files, lint.File{Path: file.Path, Package: name, Content: content})
+		}
+	}
+	return m.Lint, files, nil
+}
+
+// isInstructionFile reports whether a file is markdown or a Cursor rule
+func isInstructionFile(filePath string) bool {
+	ext := strings.ToLower(path.Ext(filePath))
+	return ext == ".md" || ext == ".mdc"
+}
+
+// printLintReport prints the findings with paths relative to the current
+// directory
+func printLintReport(report lintReport, dir string, errorCount, warningCount int, failed bool) {
+	for _, finding := range report.Findings {
+		finding.File = filepath.Join(dir, filepath.FromSlash(finding.File))
+		if finding.Severity == lint.SeverityError {
+			color.Red("✗ %s", finding)
+		} else {
+			color.Yellow("⚠ %s", finding)
+		}
+	}
+	if len(report.Findings) > 0 {
+		fmt.Println()
+	}
+
+	switch {
+	case failed:
+		color.Red("✗ %d error(s), %d warning(s) in %d file(s)", errorCount, warningCount, report.Files)
+	case warningCount > 0:
+		color.Yellow("⚠ %d warning(s) in %d file(s)", warningCount, report.Files)
+	default:
+		color.Green("✓ %d file(s) passed all lint rules", report.Files)
+	}
+}
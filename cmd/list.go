@@ -3,13 +3,14 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"skillmaster/pkg/config"
 	"skillmaster/pkg/github"
-	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
 	"skillmaster/pkg/manifest"
 )
 
@@ -20,6 +21,10 @@ var listCmd = &cobra.Command{
 	RunE:  runList,
 }
 
+func init() {
+	listCmd.Flags().StringSliceP("profile", "p", nil, "List packages installed for the given assistant profiles")
+}
+
 func runList(cmd *cobra.Command, args []string) error {
 	// Get current directory
 	cwd, err := os.Getwd()
@@ -42,43 +47,131 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Resolve the directories to inspect
+	profiles, _ := cmd.Flags().GetStringSlice("profile")
+	var targets []installTarget
+	if len(profiles) > 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		targets, err = resolveInstallTargets(m, cfg, profiles)
+		if err != nil {
+			return err
+		}
+	} else {
+		targets = []installTarget{{InstallDir: m.Config.InstallDir}}
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		listTarget(m, lock, cwd, target)
+	}
+
+	return nil
+}
+
+// listTarget prints the packages installed in a single installation target
+func listTarget(m *manifest.Manifest, lock *lockfile.LockFile, cwd string, target installTarget) {
 	// Print header
 	fmt.Println()
-	color.Cyan("Installed Packages")
+	if target.Profile != "" {
+		color.Cyan("Installed Packages (%s)", target.Profile)
+	} else {
+		color.Cyan("Installed Packages")
+	}
 	fmt.Println(strings.Repeat("─", 70))
 	fmt.Printf("%-40s %-15s %s\n", "Package", "Version", "Files")
 	fmt.Println(strings.Repeat("─", 70))
 
-	// Get installation directory
-	installDir := filepath.Join(cwd, m.Config.InstallDir)
-
 	// List all dependencies
-	for packageName, version := range m.Dependencies {
+	for _, packageName := range m.DependencyNames() {
+		version := m.Dependencies[packageName]
+
 		// Parse package name
-		owner, repo, err := github.ParseRepoURL(packageName)
-		if err != nil {
+		if _, _, err := github.ParseRepoURL(packageName); err != nil {
 			color.Red("✗ Invalid package name: %s", packageName)
 			continue
 		}
 
 		// Count installed files
-		fileCount, err := installer.CountInstalledFiles(installDir, owner, repo)
-		if err != nil {
-			color.Yellow("⚠ %s", err)
-			fileCount = 0
-		}
+		fileCount := installedFileCount(cwd, lock, packageName, target)
 
 		// Print package info
-		if fileCount > 0 {
-			fmt.Printf("%-40s %-15s %d file(s)\n", packageName, version, fileCount)
-		} else {
-			fmt.Printf("%-40s %-15s %s\n", packageName, version, color.RedString("not installed"))
+		locked := lock.Package(packageName)
+		printListRow(packageName, version, fileCount, locked)
+
+		// Print metadata from skillmaster-package.json
+		if locked != nil && locked.Metadata != nil {
+			printPackageMetadata(locked.Metadata)
+		}
+	}
+
+	// List the packages installed as dependencies of others
+	var transitive []string
+	for name, locked := range lock.Packages {
+		if _, ok := m.Dependencies[name]; !ok && locked.Transitive {
+			transitive = append(transitive, name)
+		}
+	}
+	sort.Strings(transitive)
+	for _, packageName := range transitive {
+		locked := lock.Package(packageName)
+		fileCount := installedFileCount(cwd, lock, packageName, target)
+		printListRow(packageName, locked.Version, fileCount, locked)
+		color.New(color.Faint).Printf("  dependency of %s\n", strings.Join(lock.Dependents(packageName), ", "))
+		if locked.Metadata != nil {
+			printPackageMetadata(locked.Metadata)
 		}
 	}
 
 	fmt.Println(strings.Repeat("─", 70))
 	fmt.Println()
-	color.Blue("ℹ Installation directory: %s", m.Config.InstallDir)
+	color.Blue("ℹ Installation directory: %s", target.InstallDir)
+}
 
-	return nil
+// printListRow prints the row of a package. Linked packages show "linked"
+// as their version, followed by their directory and pinned version.
+func printListRow(packageName, version string, fileCount int, locked *lockfile.LockedPackage) {
+	linked := locked != nil && locked.Link != ""
+	if linked {
+		version = color.MagentaString("%-15s", "linked")
+	} else {
+		version = fmt.Sprintf("%-15s", version)
+	}
+
+	if fileCount > 0 {
+		fmt.Printf("%-40s %s %d file(s)\n", packageName, version, fileCount)
+	} else {
+		fmt.Printf("%-40s %s %s\n", packageName, version, color.RedString("not installed"))
+	}
+
+	if linked {
+		color.Magenta("  → %s (pinned %s)", locked.Link, locked.Version)
+	}
+}
+
+// printPackageMetadata prints the description, license and keywords of a
+// package below its row
+func printPackageMetadata(metadata *lockfile.Metadata) {
+	var details []string
+	if metadata.License != "" {
+		details = append(details, metadata.License)
+	}
+	if len(metadata.Keywords) > 0 {
+		details = append(details, strings.Join(metadata.Keywords, ", "))
+	}
+
+	faint := color.New(color.Faint)
+	if metadata.Description != "" {
+		faint.Printf("  %s\n", metadata.Description)
+	}
+	if len(details) > 0 {
+		faint.Printf("  %s\n", strings.Join(details, " · "))
+	}
 }
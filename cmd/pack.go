@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/pack"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var packCmd = &cobra.Command{
+	Use:   "pack [dir]",
+	Short: "Build a distributable archive of a package",
+	Long: `Pack the package in the given directory, or the current one, into a
+name-version.tgz archive.
+
+The archive contains exactly the files a consumer would install according to
+skillmaster-package.json, the skillmaster-package.json itself and an embedded
+skillmaster-pack.json listing every file with its size and SHA-256 hash.
+Entries are sorted and have fixed times, owners and modes, so packing the same
+files always produces the same archive and integrity string.
+
+Examples:
+  skillmaster pack
+  skillmaster pack ./go-style --out dist
+  skillmaster pack --dry-run
+  skillmaster pack --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPack,
+}
+
+func init() {
+	packCmd.Flags().StringP("out", "o", ".", "Directory to write the archive to")
+	packCmd.Flags().Bool("dry-run", false, "List the files that would be packed without writing the archive")
+	packCmd.Flags().Bool("json", false, "Print the archive details as JSON")
+}
+
+func runPack(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	outDir, _ := cmd.Flags().GetString("out")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	archive, err := pack.Pack(dir)
+	if err != nil {
+		return err
+	}
+
+	archivePath := filepath.Join(outDir, archive.FileName)
+	if !dryRun {
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", outDir, err)
+		}
+		if err := os.WriteFile(archivePath, archive.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", archivePath, err)
+		}
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(archive, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal archive details: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	color.Cyan("%s@%s", archive.Index.Name, archive.Index.Version)
+	total := 0
+	for _, file := range archive.Index.Files {
+		fmt.Printf("  %-8s %s\n", installer.FormatSize(file.Size), file.Path)
+		total += file.Size
+	}
+	fmt.Println()
+	fmt.Printf("%-10s %s\n", "files:", fmt.Sprintf("%d (%s unpacked)", len(archive.Index.Files), installer.FormatSize(total)))
+	fmt.Printf("%-10s %s\n", "size:", installer.FormatSize(len(archive.Content)))
+	fmt.Printf("%-10s %s\n", "sha256:", archive.SHA256)
+	fmt.Printf("%-10s %s\n", "integrity:", archive.Integrity)
+	fmt.Println()
+
+	if dryRun {
+		color.Blue("ℹ Dry run: %s was not written", archive.FileName)
+		return nil
+	}
+	color.Green("✓ Packed %s", archivePath)
+	return nil
+}
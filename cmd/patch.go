@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/patch"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// patchWorkDir holds package snapshots being edited
+	patchWorkDir = ".skillmaster/patch"
+	// patchesDir holds committed patches
+	patchesDir = "patches"
+)
+
+var patchCmd = &cobra.Command{
+	Use:   "patch <owner/repo>",
+	Short: "Customize a package with a patch that survives reinstalls",
+	Long: `Customize an installed package with small edits.
+
+patch snapshots the package files, with overrides and existing patches
+applied, into .skillmaster/patch/owner-repo/edit/. Edit the files there, then
+run patch --commit to store the changes as a unified diff in
+patches/owner-repo.patch. The patch is referenced from skillmaster.json and
+re-applied on every install; when upstream changes make it no longer apply,
+the install fails and lists the conflicting hunks.
+
+Examples:
+  skillmaster patch company/react-guide           # Snapshot the package for editing
+  skillmaster patch company/react-guide --commit  # Save the edits as a patch
+  skillmaster patch company/react-guide --abort   # Discard the snapshot`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPatch,
+}
+
+func init() {
+	patchCmd.Flags().Bool("commit", false, "Store the edits of the snapshot as a patch")
+	patchCmd.Flags().Bool("abort", false, "Discard the snapshot without saving a patch")
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Parse package name
+	owner, repo, err := github.ParseRepoURL(args[0])
+	if err != nil {
+		return err
+	}
+	packageName := fmt.Sprintf("%s/%s", owner, repo)
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+	locked := lock.Package(packageName)
+	if _, ok := m.Dependencies[packageName]; !ok && locked == nil {
+		return fmt.Errorf("package not installed: %s", packageName)
+	}
+
+	workDir := filepath.Join(cwd, filepath.FromSlash(patchWorkDir), fmt.Sprintf("%s-%s", owner, repo))
+
+	commit, _ := cmd.Flags().GetBool("commit")
+	abort, _ := cmd.Flags().GetBool("abort")
+	switch {
+	case abort:
+		if err := os.RemoveAll(workDir); err != nil {
+			return fmt.Errorf("failed to remove snapshot: %w", err)
+		}
+		color.Blue("ℹ Discarded the snapshot of %s", packageName)
+		return nil
+	case commit:
+		return commitPatch(m, cwd, packageName, workDir)
+	default:
+		// Patch the installed version
+		ref := ""
+		if locked != nil {
+			ref = locked.Version
+		}
+		return snapshotPackage(m, cwd, owner, repo, ref, workDir)
+	}
+}
+
+// snapshotPackage writes the package files before and after its patches
+// so the edits can later be diffed against the base
+func snapshotPackage(m *manifest.Manifest, cwd, owner, repo, ref, workDir string) error {
+	packageName := fmt.Sprintf("%s/%s", owner, repo)
+	editDir := filepath.Join(workDir, "edit")
+	if _, err := os.Stat(workDir); err == nil {
+		return fmt.Errorf("a snapshot of %s already exists in %s; edit it and run skillmaster patch %s --commit, or discard it with --abort",
+			packageName, relPath(cwd, editDir), packageName)
+	}
+
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Fetch the package with its overrides applied
+	color.Blue("→ Fetching %s...", packageName)
+	inst := installer.New(github.NewClient(cfg.GetGitHubToken()))
+	pkg, err := inst.FetchPackageAt(owner, repo, ref)
+	if err != nil {
+		return fmt.Errorf("failed to fetch package: %w", err)
+	}
+	pkg.Options = m.GetDependencyConfig(packageName)
+	if _, err := installer.ApplyOverrides(pkg, cwd); err != nil {
+		return fmt.Errorf("failed to apply overrides: %w", err)
+	}
+
+	// The base excludes patches so that committing replaces them
+	if err := writeSnapshot(filepath.Join(workDir, "base"), pkg.Files); err != nil {
+		return err
+	}
+
+	upstream := append([]github.FileContent{}, pkg.Files...)
+	if err := installer.ApplyPatches(pkg, cwd); err != nil {
+		pkg.Files = upstream
+		color.Yellow("⚠ The existing patches no longer apply:")
+		fmt.Println(err)
+		color.Blue("ℹ The snapshot holds the upstream files; redo your edits and commit to replace the patch")
+	}
+	if err := writeSnapshot(editDir, pkg.Files); err != nil {
+		return err
+	}
+
+	color.Green("✓ Snapshot of %s written to %s/", packageName, relPath(cwd, editDir))
+	fmt.Println()
+	fmt.Println("Edit the files, then save your changes with:")
+	fmt.Printf("  %s\n", color.CyanString("skillmaster patch %s --commit", packageName))
+	return nil
+}
+
+// commitPatch diffs the edited snapshot against its base, stores the diff
+// under patches/ and references it from the manifest
+func commitPatch(m *manifest.Manifest, cwd, packageName, workDir string) error {
+	if _, err := os.Stat(workDir); os.IsNotExist(err) {
+		return fmt.Errorf("no snapshot of %s; run skillmaster patch %s first", packageName, packageName)
+	}
+
+	base, err := readSnapshot(filepath.Join(workDir, "base"))
+	if err != nil {
+		return err
+	}
+	edit, err := readSnapshot(filepath.Join(workDir, "edit"))
+	if err != nil {
+		return err
+	}
+
+	patchPath, changed, err := savePatch(m, cwd, packageName, base, edit)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot has been saved
+	if err := os.RemoveAll(workDir); err != nil {
+		return fmt.Errorf("failed to remove snapshot: %w", err)
+	}
+
+	if changed == 0 {
+		color.Blue("ℹ No changes; %s has no patches", packageName)
+	} else {
+		color.Green("✓ Saved %d changed file(s) of %s to %s", changed, packageName, patchPath)
+	}
+	fmt.Println()
+	fmt.Println("Apply it with:")
+	fmt.Printf("  %s\n", color.CyanString("skillmaster install --force"))
+	return nil
+}
+
+// savePatch stores the differences between the base and edited package
+// files as the package's patch and references it from the manifest,
+// replacing earlier patches. Without differences the patch is removed.
+// It returns the patch path and the number of changed files.
+func savePatch(m *manifest.Manifest, cwd, packageName string, base, edit map[string][]byte) (string, int, error) {
+	// Diff every file present on either side
+	var paths []string
+	for p := range base {
+		paths = append(paths, p)
+	}
+	for p := range edit {
+		if _, ok := base[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var diffs []*patch.FileDiff
+	for _, p := range paths {
+		if diff := patch.Diff(p, base[p], edit[p]); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	owner, repo, _ := github.ParseRepoURL(packageName)
+	patchPath := path.Join(patchesDir, fmt.Sprintf("%s-%s.patch", owner, repo))
+	fullPath := filepath.Join(cwd, filepath.FromSlash(patchPath))
+
+	dc := m.DependencyConfig[packageName]
+	previous := dc.Patches
+	if len(diffs) == 0 {
+		dc.Patches = nil
+		if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+			return "", 0, fmt.Errorf("failed to remove patch: %w", err)
+		}
+	} else {
+		dc.Patches = []string{patchPath}
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return "", 0, fmt.Errorf("failed to create patches directory: %w", err)
+		}
+		if err := os.WriteFile(fullPath, patch.Format(diffs), 0644); err != nil {
+			return "", 0, fmt.Errorf("failed to write patch: %w", err)
+		}
+	}
+
+	// Update manifest
+	if m.DependencyConfig == nil {
+		m.DependencyConfig = make(map[string]manifest.DependencyConfig)
+	}
+	m.DependencyConfig[packageName] = dc
+	if dc.IsEmpty() {
+		delete(m.DependencyConfig, packageName)
+	}
+	if err := m.Save(cwd); err != nil {
+		return "", 0, fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	for _, old := range previous {
+		if old != patchPath {
+			color.Yellow("⚠ %s is included in the new patch and no longer referenced; you can delete it", old)
+		}
+	}
+	return patchPath, len(diffs), nil
+}
+
+// writeSnapshot writes package files below dir
+func writeSnapshot(dir string, files []github.FileContent) error {
+	outputFiles := make([]installer.OutputFile, 0, len(files))
+	for _, file := range files {
+		outputFiles = append(outputFiles, installer.OutputFile{Path: file.Path, Content: file.Content})
+	}
+	if _, err := installer.WriteFiles(dir, outputFiles); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// readSnapshot reads the files below dir, keyed by slash-separated path
+func readSnapshot(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return files, nil
+}
+
+// relPath returns target relative to dir for display
+func relPath(dir, target string) string {
+	if rel, err := filepath.Rel(dir, target); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return target
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+)
+
+// stdinReader reads interactive answers; it is shared so buffered input
+// is not lost between prompts
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// installJob is a fetched package and the targets it is installed into
+type installJob struct {
+	Name    string
+	Version string
+	Package *installer.Package
+	Targets []installTarget
+	Link    string // local directory of a linked package
+
+	upstream  []github.FileContent   // package files before overrides and patches
+	installed lockfile.LockedPackage // lock entry before this install
+}
+
+// targetPlan holds the files a package writes into one target
+type targetPlan struct {
+	Job    *installJob
+	Target installTarget
+	Name   string // resolved target name
+	Files  []installer.OutputFile
+	Count  int
+	Err    error
+}
+
+// filePath returns the path of an output file relative to the project root
+func (p *targetPlan) filePath(file installer.OutputFile) string {
+	return path.Join(filepath.ToSlash(p.Target.InstallDir), file.Path)
+}
+
+// writeJobs plans the files of every job, protects local changes to
+// installed files, resolves files claimed by several packages using the
+// conflict policy, then writes the files and records them in the lock file.
+// Errors of a single target are kept on its plan, as are the local changes
+// that make a package be skipped.
+func writeJobs(jobs []*installJob, m *manifest.Manifest, lock *lockfile.LockFile, cwd string, opts installOptions) ([]*targetPlan, error) {
+	// Plan the output files of every target
+	var plans []*targetPlan
+	replanned := make(map[string]bool)
+	for _, job := range jobs {
+		job.upstream = append([]github.FileContent{}, job.Package.Files...)
+		if locked := lock.Package(job.Name); locked != nil {
+			job.installed = *locked
+		}
+		jobPlans, overrideWarnings, err := planJob(job, lock, cwd)
+		if err != nil {
+			return nil, err
+		}
+
+		// Do not overwrite files changed since they were installed
+		changes, err := localChanges(job, jobPlans, lock, cwd)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 && opts.Force {
+			color.Yellow("⚠ Overwriting %d locally changed file(s) of %s", len(changes), job.Name)
+		} else if len(changes) > 0 {
+			converted, err := protectLocalChanges(job, jobPlans, changes, m, cwd)
+			if err != nil {
+				return nil, err
+			}
+			if !converted {
+				// Skip the package and keep its lock entry; the other
+				// packages are still installed
+				*lock.Package(job.Name) = job.installed
+				refusal := fmt.Errorf("refusing to overwrite local changes to %s; rerun with --force to discard them", job.Name)
+				for _, plan := range jobPlans {
+					plan.Err = refusal
+				}
+				plans = append(plans, jobPlans...)
+				continue
+			}
+
+			// Plan again with the new override or patch
+			job.Package.Files = append([]github.FileContent{}, job.upstream...)
+			job.Package.Options = m.GetDependencyConfig(job.Name)
+			if jobPlans, overrideWarnings, err = planJob(job, lock, cwd); err != nil {
+				return nil, err
+			}
+		}
+		printOverrides(job.Name, lock.Package(job.Name).Overrides, overrideWarnings)
+
+		for _, plan := range jobPlans {
+			replanned[job.Name+"\x00"+plan.Target.Profile] = true
+		}
+		plans = append(plans, jobPlans...)
+	}
+
+	// Collect the paths claimed by the planned files and by installed
+	// packages that are not reinstalled. Shared files are written section
+	// by section and never conflict.
+	var claims []installer.Claim
+	for _, plan := range plans {
+		if plan.Err != nil {
+			continue
+		}
+		for _, file := range plan.Files {
+			if file.Section == "" {
+				claims = append(claims, installer.Claim{Package: plan.Job.Name, Path: plan.filePath(file)})
+			}
+		}
+	}
+	for name, locked := range lock.Packages {
+		for _, file := range locked.Files {
+			if file.Section == "" && !replanned[name+"\x00"+file.Profile] {
+				claims = append(claims, installer.Claim{Package: name, Path: file.Path, Installed: true})
+			}
+		}
+	}
+
+	conflicts := installer.DetectConflicts(claims, m.DependencyNames())
+	resolutions, err := installer.ResolveConflicts(conflicts, opts.Conflicts, chooseConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop or rename the files of packages that lost a conflict. Renamed
+	// files must not land on a path that is claimed or renamed to already.
+	used := make(map[string]bool)
+	for _, claim := range claims {
+		used[claim.Path] = true
+	}
+	for _, plan := range plans {
+		kept := plan.Files[:0]
+		for _, file := range plan.Files {
+			resolution, ok := resolutions[plan.filePath(file)]
+			if ok && resolution.Winner != plan.Job.Name {
+				if !resolution.Rename {
+					continue
+				}
+				renamed := installer.RenamedPath(file.Path, plan.Job.Package.Owner, plan.Job.Package.Repo)
+				file.Path = installer.UniquePath(renamed, func(p string) bool {
+					return used[plan.filePath(installer.OutputFile{Path: p})]
+				})
+				used[plan.filePath(file)] = true
+			}
+			kept = append(kept, file)
+		}
+		plan.Files = kept
+	}
+
+	// Installed packages that lost a path no longer own the file
+	for name, locked := range lock.Packages {
+		kept := locked.Files[:0]
+		for _, file := range locked.Files {
+			resolution, ok := resolutions[file.Path]
+			if ok && resolution.Winner != name && !replanned[name+"\x00"+file.Profile] {
+				continue
+			}
+			kept = append(kept, file)
+		}
+		locked.Files = kept
+	}
+
+	printConflicts(conflicts, resolutions)
+
+	// Files the lock file does not know would be overwritten
+	untracked := untrackedFiles(plans, lock, cwd)
+	if len(untracked) > 0 {
+		if !opts.Force {
+			return nil, fmt.Errorf("refusing to overwrite %d file(s) not installed by skillmaster:\n  %s\nmove them away or rerun with --force",
+				len(untracked), strings.Join(untracked, "\n  "))
+		}
+		color.Yellow("⚠ Overwriting %d file(s) not installed by skillmaster", len(untracked))
+	}
+
+	// Remove stale files of every target before writing, so a path that
+	// moved from one package to another is not deleted after it was written
+	for _, plan := range plans {
+		if plan.Err != nil {
+			continue
+		}
+		written := make(map[string]bool)
+		for _, file := range plan.Files {
+			written[plan.filePath(file)] = true
+		}
+		var stale []lockfile.LockedFile
+		if locked := lock.Package(plan.Job.Name); locked != nil {
+			for _, file := range locked.FilesFor(plan.Target.Profile) {
+				// A path lost to another package now holds the winner's file
+				if resolution, ok := resolutions[file.Path]; ok && resolution.Winner != plan.Job.Name {
+					continue
+				}
+				if !written[file.Path] {
+					stale = append(stale, file)
+				}
+			}
+		}
+		if err := removeLockedFiles(cwd, stale); err != nil {
+			plan.Err = err
+		}
+	}
+
+	// Write the files and record them in the lock file
+	for _, plan := range plans {
+		if plan.Err != nil {
+			continue
+		}
+		plan.Count, plan.Err = installer.WriteFiles(plan.Target.Path(cwd), plan.Files)
+		if plan.Err != nil {
+			continue
+		}
+
+		lockedFiles := make([]lockfile.LockedFile, 0, len(plan.Files))
+		for _, file := range plan.Files {
+			lockedFiles = append(lockedFiles, lockfile.LockedFile{
+				Path:    plan.filePath(file),
+				Profile: plan.Target.Profile,
+				Target:  plan.Name,
+				Source:  file.Source,
+				Section: file.Section,
+				Hash:    installer.FileHash(file),
+			})
+		}
+		lock.Package(plan.Job.Name).SetFiles(plan.Target.Profile, lockedFiles)
+	}
+
+	return plans, nil
+}
+
+// planJob applies the overrides and patches of a job's package and plans
+// the files of each of its targets. It returns the warnings about the
+// overrides, which are printed once the job's plan is final.
+func planJob(job *installJob, lock *lockfile.LockFile, cwd string) ([]*targetPlan, []string, error) {
+	// Replace upstream files with the project's overrides and patches
+	overrides, err := installer.ApplyOverrides(job.Package, cwd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply overrides of %s: %w", job.Name, err)
+	}
+	if err := installer.ApplyPatches(job.Package, cwd); err != nil {
+		return nil, nil, fmt.Errorf("failed to patch %s: %w\nrun skillmaster patch %s to update the patch", job.Name, err, job.Name)
+	}
+	locked := lock.SetPackage(job.Name, job.Version, job.Package.Ref)
+	locked.Link = job.Link
+	var warnings []string
+	locked.Overrides, warnings = checkOverrides(&job.installed, overrides)
+	locked.Metadata = nil
+	if pm := job.Package.Manifest; pm != nil {
+		locked.Metadata = &lockfile.Metadata{
+			Name:        pm.Name,
+			Version:     pm.Version,
+			Description: pm.Description,
+			Author:      pm.Author,
+			License:     pm.License,
+			Keywords:    pm.Keywords,
+		}
+	}
+
+	var plans []*targetPlan
+	for _, target := range job.Targets {
+		plan := &targetPlan{Job: job, Target: target}
+		plans = append(plans, plan)
+
+		t, err := installer.NewTarget(target.Target, installer.TargetOptions{Compose: target.Compose})
+		if err != nil {
+			plan.Err = err
+			continue
+		}
+		plan.Name = t.Name()
+		plan.Files, plan.Err = t.Files(job.Package)
+	}
+	return plans, warnings, nil
+}
+
+// checkOverrides compares the applied overrides with those recorded by the
+// previous install. It returns the override records to keep in the lock
+// file and warns when the upstream file an override replaces changed since
+// the override was last edited.
+func checkOverrides(installed *lockfile.LockedPackage, overrides []installer.Override) ([]lockfile.LockedOverride, []string) {
+	var records []lockfile.LockedOverride
+	var warnings []string
+	for _, override := range overrides {
+		record := lockfile.LockedOverride{
+			Source:       override.Source,
+			Path:         override.Path,
+			OverrideHash: lockfile.Hash(override.Content),
+		}
+		if override.Upstream != nil {
+			record.UpstreamHash = lockfile.Hash(override.Upstream)
+		}
+
+		// Keep warning until the override is edited, which acknowledges
+		// the new upstream content
+		previous := installed.Override(override.Source)
+		if previous != nil && previous.OverrideHash == record.OverrideHash && previous.UpstreamHash != record.UpstreamHash {
+			if override.Upstream == nil {
+				warnings = append(warnings, fmt.Sprintf("%s was removed upstream but is still overridden by %s", override.Source, override.Path))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s changed upstream since %s was written; review the override", override.Source, override.Path))
+			}
+			record.UpstreamHash = previous.UpstreamHash
+		} else if override.Upstream == nil && previous == nil {
+			warnings = append(warnings, fmt.Sprintf("%s does not exist upstream; %s is installed as an extra file", override.Source, override.Path))
+		}
+
+		records = append(records, record)
+	}
+	return records, warnings
+}
+
+// printOverrides reports the overrides applied to a package and the
+// warnings about them
+func printOverrides(packageName string, records []lockfile.LockedOverride, warnings []string) {
+	for _, warning := range warnings {
+		color.Yellow("⚠ %s: %s", packageName, warning)
+	}
+	if len(records) > 0 {
+		color.Blue("ℹ %s: applied %d override(s)", packageName, len(records))
+	}
+}
+
+// printConflicts reports how each conflict was resolved
+func printConflicts(conflicts []installer.Conflict, resolutions map[string]installer.Resolution) {
+	for _, conflict := range conflicts {
+		resolution := resolutions[conflict.Path]
+		var others []string
+		for _, name := range conflict.Packages {
+			if name != resolution.Winner {
+				others = append(others, name)
+			}
+		}
+		if resolution.Rename {
+			color.Yellow("⚠ Conflict on %s: kept %s, renamed files of %s", conflict.Path, resolution.Winner, strings.Join(others, ", "))
+		} else {
+			color.Yellow("⚠ Conflict on %s: kept %s, skipped %s", conflict.Path, resolution.Winner, strings.Join(others, ", "))
+		}
+	}
+}
+
+// chooseConflict asks which package keeps a conflicting path.
+// It returns "" when the other packages' files should be renamed.
+func chooseConflict(conflict installer.Conflict) (string, error) {
+	color.Yellow("⚠ %s is written by several packages:", conflict.Path)
+	for i, name := range conflict.Packages {
+		note := ""
+		for _, installed := range conflict.Installed {
+			if installed == name {
+				note = " (installed)"
+			}
+		}
+		fmt.Printf("  %d) %s%s\n", i+1, name, note)
+	}
+	fmt.Println("  r) keep all, renaming the other packages' files")
+
+	for {
+		fmt.Print("Keep which package? [1]: ")
+		response, err := stdinReader.ReadString('\n')
+		response = strings.TrimSpace(response)
+		if err != nil && response == "" {
+			return "", fmt.Errorf("no conflict resolution given for %s", conflict.Path)
+		}
+
+		switch {
+		case response == "":
+			return conflict.Packages[0], nil
+		case strings.EqualFold(response, "r"):
+			return "", nil
+		}
+		if n, err := strconv.Atoi(response); err == nil && n >= 1 && n <= len(conflict.Packages) {
+			return conflict.Packages[n-1], nil
+		}
+		color.Red("✗ Enter a number between 1 and %d, or r", len(conflict.Packages))
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/manifest"
+)
+
+// installTarget is a resolved installation location for a profile
+type installTarget struct {
+	Profile    string // empty for the project's default install directory
+	InstallDir string // relative to the project root
+	Target     string // install target name, empty for the default layout
+	Compose    bool   // compose a single shared instructions file if the target supports it
+}
+
+// Label returns a human readable name for the target
+func (t installTarget) Label() string {
+	if t.Profile == "" {
+		return t.InstallDir
+	}
+	return fmt.Sprintf("%s (%s)", t.Profile, t.InstallDir)
+}
+
+// Path returns the absolute installation directory for the target
+func (t installTarget) Path(cwd string) string {
+	return filepath.Join(cwd, t.InstallDir)
+}
+
+// resolveInstallTargets maps profile names to installation directories.
+// Project profiles in skillmaster.json take precedence over global ones.
+// When no profiles are given, the manifest's installDir is used.
+func resolveInstallTargets(m *manifest.Manifest, cfg *config.GlobalConfig, profiles []string) ([]installTarget, error) {
+	if len(profiles) == 0 {
+		return []installTarget{{InstallDir: m.Config.InstallDir}}, nil
+	}
+
+	var targets []installTarget
+	seen := make(map[string]bool)
+	for _, name := range profiles {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if profile, ok := m.Config.Profiles[name]; ok && profile.InstallDir != "" {
+			targets = append(targets, installTarget{Profile: name, InstallDir: profile.InstallDir, Target: profile.Target, Compose: profile.Compose})
+			continue
+		}
+		if profile, ok := cfg.GetProfile(name); ok && profile.InstallDir != "" {
+			targets = append(targets, installTarget{Profile: name, InstallDir: profile.InstallDir, Target: profile.Target, Compose: profile.Compose})
+			continue
+		}
+
+		return nil, fmt.Errorf("unknown profile: %s (available: %s)", name, strings.Join(availableProfiles(m, cfg), ", "))
+	}
+
+	return targets, nil
+}
+
+// availableProfiles returns the names of all profiles known to the project
+func availableProfiles(m *manifest.Manifest, cfg *config.GlobalConfig) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for name := range m.Config.Profiles {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for name := range cfg.Profiles {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+)
+
+// localChanges returns the installed files of a job's targets that were
+// modified or deleted since they were installed
+func localChanges(job *installJob, plans []*targetPlan, lock *lockfile.LockFile, cwd string) ([]installer.Change, error) {
+	locked := lock.Package(job.Name)
+	if locked == nil {
+		return nil, nil
+	}
+
+	var files []lockfile.LockedFile
+	for _, plan := range plans {
+		files = append(files, locked.FilesFor(plan.Target.Profile)...)
+	}
+
+	changes, err := installer.DetectChanges(cwd, job.Package.Namespace(), lock, files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Untracked files in the package's directories are left alone
+	kept := changes[:0]
+	for _, change := range changes {
+		if change.Kind != installer.ChangeAdded {
+			kept = append(kept, change)
+		}
+	}
+	return kept, nil
+}
+
+// untrackedFiles returns the planned paths that exist on disk but were not
+// installed by any package. Files in the owner-repo directory of a package
+// installed before the lock file existed are expected.
+func untrackedFiles(plans []*targetPlan, lock *lockfile.LockFile, cwd string) []string {
+	tracked := make(map[string]bool)
+	for _, locked := range lock.Packages {
+		for _, file := range locked.Files {
+			tracked[file.Path] = true
+		}
+	}
+
+	var untracked []string
+	for _, plan := range plans {
+		if plan.Err != nil {
+			continue
+		}
+		namespace := "/" + plan.Job.Package.Namespace() + "/"
+		for _, file := range plan.Files {
+			filePath := plan.filePath(file)
+			if file.Section != "" || tracked[filePath] || strings.Contains("/"+filePath, namespace) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(cwd, filepath.FromSlash(filePath))); err == nil {
+				untracked = append(untracked, filePath)
+			}
+		}
+	}
+	return untracked
+}
+
+// protectLocalChanges stops a reinstall from discarding local changes.
+// Changes to files of the default target can be kept as an override or a
+// patch instead; it returns true when they were and the job has to be
+// planned again, and false when the package must be skipped.
+func protectLocalChanges(job *installJob, plans []*targetPlan, changes []installer.Change, m *manifest.Manifest, cwd string) (bool, error) {
+	color.Yellow("⚠ %d installed file(s) of %s changed since they were installed:", len(changes), job.Name)
+	for _, change := range changes {
+		fmt.Printf("  %-9s %s\n", change.Kind, change.Path)
+	}
+
+	if reason := unconvertible(plans, changes); reason != "" {
+		color.Blue("ℹ The changes cannot be kept as an override or patch: %s", reason)
+		return false, nil
+	}
+
+	canOverride := len(job.Package.Options.Patches) == 0
+	for _, change := range changes {
+		if change.Kind != installer.ChangeModified {
+			canOverride = false
+		}
+	}
+
+	if canOverride {
+		fmt.Printf("Keep the changes as an [o]verride, a [p]atch, or [s]kip %s? (o/p/S): ", job.Name)
+	} else {
+		fmt.Printf("Keep the changes as a [p]atch, or [s]kip %s? (p/S): ", job.Name)
+	}
+	response, _ := stdinReader.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+
+	switch {
+	case response == "o" && canOverride:
+		return true, saveChangesAsOverrides(job, changes, cwd)
+	case response == "p":
+		return true, saveChangesAsPatch(job, changes, m, cwd)
+	}
+	return false, nil
+}
+
+// unconvertible explains why changes cannot be turned into an override or
+// patch, or returns "" when they can. Only files of the default target hold
+// the package content as-is, and only while upstream is unchanged does the
+// difference to the installed file consist of the local edits alone.
+func unconvertible(plans []*targetPlan, changes []installer.Change) string {
+	for _, change := range changes {
+		if change.File.Target != installer.TargetDefault || change.File.Section != "" || change.File.Source == "" {
+			return fmt.Sprintf("%s was generated by the %s target", change.Path, change.File.Target)
+		}
+		if change.File.Hash == "" {
+			return fmt.Sprintf("%s was installed before file hashes were recorded", change.Path)
+		}
+
+		unchanged := false
+		for _, plan := range plans {
+			if plan.Target.Profile != change.File.Profile {
+				continue
+			}
+			for _, file := range plan.Files {
+				if plan.filePath(file) == change.Path && installer.FileHash(file) == change.File.Hash {
+					unchanged = true
+				}
+			}
+		}
+		if !unchanged {
+			return fmt.Sprintf("%s changed upstream since it was installed", change.File.Source)
+		}
+	}
+	return ""
+}
+
+// saveChangesAsOverrides copies modified installed files to their overrides
+func saveChangesAsOverrides(job *installJob, changes []installer.Change, cwd string) error {
+	for _, change := range changes {
+		content, err := os.ReadFile(filepath.Join(cwd, filepath.FromSlash(change.Path)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", change.Path, err)
+		}
+
+		overridePath := job.Package.Options.Overrides[change.File.Source]
+		if overridePath == "" {
+			overridePath = path.Join(installer.OverridesDir, job.Package.Namespace(), change.File.Source)
+		}
+		fullPath := filepath.Join(cwd, filepath.FromSlash(overridePath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("failed to create override directory: %w", err)
+		}
+		if err := os.WriteFile(fullPath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write override: %w", err)
+		}
+		color.Green("✓ Saved %s as override %s", change.Path, overridePath)
+	}
+	return nil
+}
+
+// saveChangesAsPatch folds the local changes into the package's patch
+func saveChangesAsPatch(job *installJob, changes []installer.Change, m *manifest.Manifest, cwd string) error {
+	// The base is the upstream content with overrides, as for patch --commit
+	pkg := *job.Package
+	pkg.Files = append([]github.FileContent{}, job.upstream...)
+	if _, err := installer.ApplyOverrides(&pkg, cwd); err != nil {
+		return fmt.Errorf("failed to apply overrides: %w", err)
+	}
+	base := make(map[string][]byte)
+	for _, file := range pkg.Files {
+		base[file.Path] = file.Content
+	}
+
+	// The edits are the existing patches plus the local changes
+	if err := installer.ApplyPatches(&pkg, cwd); err != nil {
+		return err
+	}
+	edit := make(map[string][]byte)
+	for _, file := range pkg.Files {
+		edit[file.Path] = file.Content
+	}
+	for _, change := range changes {
+		if change.Kind == installer.ChangeDeleted {
+			delete(edit, change.File.Source)
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(cwd, filepath.FromSlash(change.Path)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", change.Path, err)
+		}
+		edit[change.File.Source] = content
+	}
+
+	patchPath, _, err := savePatch(m, cwd, job.Name, base, edit)
+	if err != nil {
+		return err
+	}
+	color.Green("✓ Saved the changes to %s", patchPath)
+	return nil
+}
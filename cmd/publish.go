@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/publish"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish the tagged version of a package as a GitHub release",
+	Long: `Publish the package in the current directory.
+
+The package is validated first and must be checked out at the tag of its
+version (created with skillmaster version), with no uncommitted changes to
+the files it packs. The tag is pushed, and a GitHub release is created for it with
+a packed archive of the package and its SHA-256 checksum attached. The release
+notes come from the version's section of CHANGELOG.md, or are generated by
+GitHub when there is none. Finally the repository gets the skillmaster-package
+topic so that search finds it.
+
+Publishing requires a GitHub token with access to the repository in
+~/.skillmaster/config.json.
+
+Examples:
+  skillmaster version minor && skillmaster publish
+  skillmaster publish --dry-run
+  skillmaster publish --repo acme/typescript-style --no-push`,
+	Args: cobra.NoArgs,
+	RunE: runPublish,
+}
+
+func init() {
+	publishCmd.Flags().String("repo", "", "GitHub repository (owner/repo); defaults to the remote's")
+	publishCmd.Flags().String("remote", "origin", "Git remote to push the tag to")
+	publishCmd.Flags().Bool("no-push", false, "Do not push the tag, for example when it was pushed already")
+	publishCmd.Flags().Bool("dry-run", false, "Validate and pack the package without publishing it")
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	repo, _ := cmd.Flags().GetString("repo")
+	remote, _ := cmd.Flags().GetString("remote")
+	noPush, _ := cmd.Flags().GetBool("no-push")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	plan, err := publish.Prepare(cwd, publish.Options{Repo: repo, Remote: remote, Push: !noPush})
+	if err != nil {
+		return err
+	}
+
+	color.Green("✓ Package is valid")
+	color.Green("✓ Packed %s (%d files, %s)", plan.Archive.FileName, len(plan.Archive.Index.Files), installer.FormatSize(len(plan.Archive.Content)))
+	fmt.Printf("  sha256 %s\n", plan.Archive.SHA256)
+	if plan.Notes != "" {
+		color.Blue("ℹ Release notes from CHANGELOG.md")
+	} else {
+		color.Blue("ℹ No CHANGELOG.md section for %s; GitHub will generate the release notes", plan.Version)
+	}
+
+	if dryRun {
+		fmt.Println()
+		color.Yellow("Dry run: would publish %s to %s/%s", plan.Tag, plan.Owner, plan.Repo)
+		if plan.Push {
+			fmt.Printf("  push tag %s to %s\n", plan.Tag, plan.Remote)
+		}
+		fmt.Printf("  create release %s with %s and %s\n", plan.Tag, plan.Archive.FileName, plan.ChecksumFileName())
+		fmt.Printf("  add the %s topic if missing\n", github.PackageTopic)
+		return nil
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.GetGitHubToken() == "" {
+		return fmt.Errorf("publishing requires a GitHub token; add one to ~/.skillmaster/config.json")
+	}
+
+	fmt.Println()
+	color.Cyan("→ Publishing %s to %s/%s...", plan.Tag, plan.Owner, plan.Repo)
+	result, err := plan.Execute(github.NewClient(cfg.GetGitHubToken()))
+	if err != nil {
+		return err
+	}
+
+	if result.Pushed {
+		color.Green("✓ Pushed tag %s to %s", plan.Tag, plan.Remote)
+	}
+	color.Green("✓ Created release %s", result.Release.Name)
+	for _, asset := range result.Assets {
+		fmt.Printf("  %s\n", asset)
+	}
+	if result.TopicAdded {
+		color.Green("✓ Added the %s topic", github.PackageTopic)
+	}
+	if result.Release.URL != "" {
+		color.Blue("ℹ %s", result.Release.URL)
+	}
+	color.Blue("ℹ Others can install it with: skillmaster install %s/%s", plan.Owner, plan.Repo)
+	return nil
+}
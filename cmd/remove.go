@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:     "remove <owner/repo>",
+	Aliases: []string{"uninstall", "rm"},
+	Short:   "Remove an installed package",
+	Long: `Remove a package from the project.
+
+Deletes every file the package installed, in all profiles and install targets
+(including generated Cursor rules, Claude skills and Copilot instructions), and
+removes it from skillmaster.json and skillmaster.lock. Sections the package
+added to shared files such as copilot-instructions.md are removed as well.
+Dependencies no other package requires are removed with it; a package other
+packages depend on stays installed as their dependency.
+
+Files changed since they were installed are not deleted unless --force is
+given. Files you added to the package's directories are kept.
+
+Example:
+  skillmaster remove anthropic/claude-best-practices`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRemove,
+}
+
+func init() {
+	removeCmd.Flags().BoolP("force", "f", false, "Remove files even if they were changed since they were installed")
+}
+
+func runRemove(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Parse package name
+	owner, repo, err := github.ParseRepoURL(args[0])
+	if err != nil {
+		return err
+	}
+	packageName := fmt.Sprintf("%s/%s", owner, repo)
+
+	_, inManifest := m.Dependencies[packageName]
+	locked := lock.Package(packageName)
+	if !inManifest && locked == nil {
+		return fmt.Errorf("package not installed: %s", packageName)
+	}
+
+	// Packages other installed packages depend on stay installed
+	var dependents []string
+	for _, name := range lock.Dependents(packageName) {
+		if name != packageName {
+			dependents = append(dependents, name)
+		}
+	}
+	if locked != nil && len(dependents) > 0 {
+		if !inManifest {
+			return fmt.Errorf("%s is required by %s; remove those packages instead", packageName, strings.Join(dependents, ", "))
+		}
+		m.RemoveDependency(packageName)
+		if err := m.Save(cwd); err != nil {
+			return fmt.Errorf("failed to update manifest: %w", err)
+		}
+		locked.Transitive = true
+		if err := lock.Save(cwd); err != nil {
+			return err
+		}
+		color.Green("✓ Removed %s from skillmaster.json", packageName)
+		color.Blue("ℹ Kept it installed as a dependency of %s", strings.Join(dependents, ", "))
+		return nil
+	}
+
+	// Remove every file recorded in the lock file
+	removedCount := 0
+	if locked != nil {
+		// Do not delete files changed since they were installed
+		changes, err := installer.DetectChanges(cwd, fmt.Sprintf("%s-%s", owner, repo), lock, locked.Files)
+		if err != nil {
+			return err
+		}
+		var modified, added []string
+		for _, change := range changes {
+			switch change.Kind {
+			case installer.ChangeModified:
+				modified = append(modified, change.Path)
+			case installer.ChangeAdded:
+				added = append(added, change.Path)
+			}
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if len(modified) > 0 && !force {
+			color.Yellow("⚠ %d installed file(s) of %s changed since they were installed:", len(modified), packageName)
+			for _, filePath := range modified {
+				fmt.Printf("  %s\n", filePath)
+			}
+			return fmt.Errorf("refusing to remove local changes to %s; rerun with --force to delete them", packageName)
+		}
+
+		if err := removeLockedFiles(cwd, locked.Files); err != nil {
+			return err
+		}
+		removedCount = len(locked.Files)
+
+		if len(added) > 0 {
+			color.Blue("ℹ Kept %d file(s) not installed by skillmaster:", len(added))
+			for _, filePath := range added {
+				fmt.Printf("  %s\n", filePath)
+			}
+		}
+	} else {
+		// Remove the owner-repo directory left by installs that predate the lock file
+		installDir := filepath.Join(cwd, m.Config.InstallDir)
+		if count, err := installer.CountInstalledFiles(installDir, owner, repo); err == nil && count > 0 {
+			if err := installer.New(nil).UninstallPackage(owner, repo, installDir); err != nil {
+				return err
+			}
+			removedCount += count
+		}
+	}
+
+	// Update manifest and lock file
+	m.RemoveDependency(packageName)
+	if err := m.Save(cwd); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	lock.RemovePackage(packageName)
+
+	// Remove the dependencies only this package required
+	if err := removeOrphans(m, lock, cwd); err != nil {
+		return err
+	}
+
+	if err := lock.Save(cwd); err != nil {
+		return err
+	}
+
+	color.Green("✓ Removed %s (%d files)", packageName, removedCount)
+
+	// Refresh composed instruction files
+	if composeOnInstall(cmd, m) {
+		return composeFiles(cwd, m, configuredComposeFiles(m))
+	}
+
+	return nil
+}
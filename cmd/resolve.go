@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/resolver"
+	"skillmaster/pkg/semver"
+
+	"github.com/fatih/color"
+)
+
+// resolveDependencies resolves the packages of the manifest and everything
+// they depend on, keeping the locked versions that still satisfy the
+// required ranges
+func resolveDependencies(githubClient *github.Client, m *manifest.Manifest, lock *lockfile.LockFile) (*resolver.Graph, error) {
+	preferred := make(map[string]string)
+	for name, locked := range lock.Packages {
+		preferred[name] = locked.Version
+	}
+
+	source := lockedSource{Source: installer.NewDependencySource(githubClient), lock: lock}
+	graph, err := resolver.Resolve(m.Dependencies, m.DependencyNames(), preferred, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+	return graph, nil
+}
+
+// lockedSource answers dependency lookups for locked releases from the lock
+// file. Tags do not move, so their dependencies only need fetching once;
+// branches are always read again.
+type lockedSource struct {
+	resolver.Source
+	lock *lockfile.LockFile
+}
+
+func (s lockedSource) Dependencies(name, version string) (map[string]string, error) {
+	if locked := s.lock.Package(name); locked != nil && locked.Version == version && locked.Dependencies != nil {
+		if _, err := semver.Parse(version); err == nil {
+			return locked.Dependencies, nil
+		}
+	}
+	return s.Source.Dependencies(name, version)
+}
+
+// recordGraph stores the resolved dependencies of every locked package
+func recordGraph(lock *lockfile.LockFile, graph *resolver.Graph) {
+	for _, name := range graph.Order {
+		locked := lock.Package(name)
+		if locked == nil {
+			continue
+		}
+		node := graph.Node(name)
+		locked.Dependencies = make(map[string]string)
+		for dep, constraint := range node.Dependencies {
+			locked.Dependencies[dep] = constraint
+		}
+		locked.Transitive = !node.Root
+	}
+}
+
+// orphanedPackages returns the locked dependencies no package of the
+// manifest requires anymore, following the graph recorded in the lock file
+func orphanedPackages(m *manifest.Manifest, lock *lockfile.LockFile) []string {
+	required := make(map[string]bool)
+	queue := m.DependencyNames()
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if required[name] {
+			continue
+		}
+		required[name] = true
+		if locked := lock.Package(name); locked != nil {
+			for dep := range locked.Dependencies {
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	var orphans []string
+	for name, locked := range lock.Packages {
+		if locked.Transitive && !required[name] {
+			orphans = append(orphans, name)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// removeOrphans deletes the files of dependencies that are no longer
+// required. Packages with local changes are kept.
+func removeOrphans(m *manifest.Manifest, lock *lockfile.LockFile, cwd string) error {
+	for _, name := range orphanedPackages(m, lock) {
+		locked := lock.Package(name)
+		namespace := name
+		if owner, repo, err := github.ParseRepoURL(name); err == nil {
+			namespace = fmt.Sprintf("%s-%s", owner, repo)
+		}
+
+		changes, err := installer.DetectChanges(cwd, namespace, lock, locked.Files)
+		if err != nil {
+			return err
+		}
+		modified := 0
+		for _, change := range changes {
+			if change.Kind == installer.ChangeModified {
+				modified++
+			}
+		}
+		if modified > 0 {
+			color.Yellow("⚠ %s is no longer required but has %d locally changed file(s); remove it with skillmaster remove --force %s", name, modified, name)
+			continue
+		}
+
+		if err := removeLockedFiles(cwd, locked.Files); err != nil {
+			return err
+		}
+		lock.RemovePackage(name)
+		color.Blue("ℹ Removed %s (no longer required, %d files)", name, len(locked.Files))
+	}
+	return nil
+}
@@ -33,7 +33,23 @@ func init() {
 	// Register subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(patchCmd)
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(treeCmd)
+	rootCmd.AddCommand(whyCmd)
+	rootCmd.AddCommand(composeCmd)
 	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(createCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(packCmd)
+	rootCmd.AddCommand(publishCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -1,30 +1,87 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"skillmaster/pkg/config"
 	"skillmaster/pkg/github"
+	"skillmaster/pkg/lockfile"
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search <query>",
+	Use:   "search [query]",
 	Short: "Search for packages on GitHub",
 	Long: `Search for SkillMaster packages on GitHub by topic and keywords.
 	
+Only repositories with the skillmaster-package topic are searched. Narrow
+the results down with --topic, --language, --owner, --min-stars and
+--updated-since, which keeps packages last pushed to since a date; without
+keywords every package matching the filters is listed. Packages installed in
+the current project (recorded in skillmaster.lock) are marked as installed.
+
 Example:
   skillmaster search react
-  skillmaster search python best-practices`,
-	Args: cobra.MinimumNArgs(1),
+  skillmaster search python best-practices
+  skillmaster search --topic claude --language go --min-stars 10
+  skillmaster search testing --owner acme --sort updated
+  skillmaster search --updated-since 90d --limit 50 --page 2
+  skillmaster search react --json`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runSearch,
 }
 
+func init() {
+	searchCmd.Flags().StringSlice("topic", nil, "Only packages with these GitHub topics (e.g. claude,cursor)")
+	searchCmd.Flags().String("language", "", "Only packages whose main language is this")
+	searchCmd.Flags().String("owner", "", "Only packages of this user or organization")
+	searchCmd.Flags().Int("min-stars", 0, "Only packages with at least this many stars")
+	searchCmd.Flags().String("updated-since", "", "Only packages last pushed to since a date (YYYY-MM-DD) or a number of days ago (e.g. 30d)")
+	searchCmd.Flags().String("sort", github.SortStars, "Order of the results: stars, updated (recently updated first) or best-match")
+	searchCmd.Flags().Int("limit", 20, "Number of results per page (at most 100)")
+	searchCmd.Flags().Int("page", 1, "Page of results to show")
+	searchCmd.Flags().Bool("json", false, "Print the results as JSON")
+}
+
+// searchReport is the JSON output of the search command
+type searchReport struct {
+	Query   string         `json:"query"` // GitHub search query
+	Total   int            `json:"total"`
+	Page    int            `json:"page"`
+	Limit   int            `json:"limit"`
+	Results []searchResult `json:"results"`
+}
+
+// searchResult is a package found by a search
+type searchResult struct {
+	Package          string   `json:"package"`
+	Description      string   `json:"description,omitempty"`
+	Stars            int      `json:"stars"`
+	UpdatedAt        string   `json:"updatedAt,omitempty"`
+	PushedAt         string   `json:"pushedAt,omitempty"` // date of the last push
+	Language         string   `json:"language,omitempty"`
+	License          string   `json:"license,omitempty"`
+	Topics           []string `json:"topics,omitempty"`
+	Installed        bool     `json:"installed"`
+	InstalledVersion string   `json:"installedVersion,omitempty"`
+}
+
 func runSearch(cmd *cobra.Command, args []string) error {
 	query := strings.Join(args, " ")
 
+	// Read the filters
+	opts, err := searchOptions(cmd, query)
+	if err != nil {
+		return err
+	}
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
 	// Load global config
 	cfg, err := config.Load()
 	if err != nil {
@@ -32,7 +89,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show warning if no GitHub token
-	if cfg.GetGitHubToken() == "" {
+	if cfg.GetGitHubToken() == "" && !jsonOutput {
 		color.Yellow("⚠ No GitHub token configured. Search results may be limited.")
 		color.Blue("ℹ Add a token to ~/.skillmaster/config.json for better results")
 		fmt.Println()
@@ -42,17 +99,53 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	githubClient := github.NewClient(cfg.GetGitHubToken())
 
 	// Search repositories
-	color.Blue("→ Searching GitHub repositories...")
-	fmt.Println()
+	if !jsonOutput {
+		color.Blue("→ Searching GitHub repositories...")
+		fmt.Println()
+	}
 
-	repos, err := githubClient.SearchRepositories(query, 20)
+	result, err := githubClient.SearchPackages(opts)
 	if err != nil {
 		return err
 	}
+	repos := result.Repositories
+
+	// Mark the packages installed in the current project
+	installed := installedPackages()
+
+	if jsonOutput {
+		report := searchReport{Query: opts.SearchQuery(), Total: result.Total, Page: opts.Page, Limit: opts.Limit, Results: []searchResult{}}
+		for _, repo := range repos {
+			packageName := fmt.Sprintf("%s/%s", repo.Owner, repo.Name)
+			version, ok := installed[strings.ToLower(packageName)]
+			report.Results = append(report.Results, searchResult{
+				Package:          packageName,
+				Description:      repo.Description,
+				Stars:            repo.Stars,
+				UpdatedAt:        repo.UpdatedAt,
+				PushedAt:         repo.PushedAt,
+				Language:         repo.Language,
+				License:          repo.License,
+				Topics:           repo.Topics,
+				Installed:        ok,
+				InstalledVersion: version,
+			})
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal search results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
 
 	// Check if no results
 	if len(repos) == 0 {
-		color.Yellow("No packages found matching: %s", query)
+		if opts.Page > 1 && result.Total > 0 {
+			color.Yellow("No more results: %d package(s) found, page %d is past the end", result.Total, opts.Page)
+			return nil
+		}
+		color.Yellow("No packages found matching: %s", opts.SearchQuery())
 		fmt.Println()
 		fmt.Println("Tips:")
 		fmt.Println("  • Try different keywords")
@@ -62,7 +155,12 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Print results
-	color.Cyan("Found %d package(s)", len(repos))
+	first := (opts.Page-1)*opts.Limit + 1
+	if result.Total > len(repos) {
+		color.Cyan("Found %d package(s), showing %d-%d", result.Total, first, first+len(repos)-1)
+	} else {
+		color.Cyan("Found %d package(s)", len(repos))
+	}
 	fmt.Println(strings.Repeat("─", 80))
 	fmt.Println()
 
@@ -70,23 +168,39 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		// Package name with stars
 		packageName := fmt.Sprintf("%s/%s", repo.Owner, repo.Name)
 		stars := fmt.Sprintf("⭐ %d", repo.Stars)
-		
-		fmt.Printf("%s %s\n", color.GreenString(packageName), color.YellowString(stars))
-		
+
+		if version, ok := installed[strings.ToLower(packageName)]; ok {
+			marker := "✓ installed"
+			if version != "" {
+				marker = fmt.Sprintf("✓ installed (%s)", version)
+			}
+			fmt.Printf("%s %s %s\n", color.GreenString(packageName), color.YellowString(stars), color.MagentaString(marker))
+		} else {
+			fmt.Printf("%s %s\n", color.GreenString(packageName), color.YellowString(stars))
+		}
+
 		// Description
 		if repo.Description != "" {
 			fmt.Printf("  %s\n", color.WhiteString(repo.Description))
 		}
-		
-		// Updated date
-		if repo.UpdatedAt != "" {
-			fmt.Printf("  %s %s\n", color.BlueString("Updated:"), repo.UpdatedAt)
+
+		// Last push date
+		if repo.PushedAt != "" {
+			fmt.Printf("  %s %s\n", color.BlueString("Last push:"), repo.PushedAt)
 		}
-		
+
+		// Language and topics
+		if repo.Language != "" {
+			fmt.Printf("  %s %s\n", color.BlueString("Language:"), repo.Language)
+		}
+		if topics := otherTopics(repo.Topics); len(topics) > 0 {
+			fmt.Printf("  %s %s\n", color.BlueString("Topics:"), strings.Join(topics, ", "))
+		}
+
 		// Install command
 		installCmd := fmt.Sprintf("skillmaster install %s", packageName)
 		fmt.Printf("  %s %s\n", color.BlueString("Install:"), color.CyanString(installCmd))
-		
+
 		// Separator between results
 		if i < len(repos)-1 {
 			fmt.Println()
@@ -96,5 +210,91 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println(strings.Repeat("─", 80))
 
+	// Point to the next page
+	last := first + len(repos) - 1
+	if last < result.Total && last < github.MaxSearchResults {
+		color.Blue("ℹ Showing %d-%d of %d; see more with --page %d", first, last, result.Total, opts.Page+1)
+	}
+
 	return nil
 }
+
+// searchOptions reads the search flags
+func searchOptions(cmd *cobra.Command, query string) (github.SearchOptions, error) {
+	opts := github.SearchOptions{Query: query}
+	opts.Topics, _ = cmd.Flags().GetStringSlice("topic")
+	opts.Language, _ = cmd.Flags().GetString("language")
+	opts.Owner, _ = cmd.Flags().GetString("owner")
+	opts.MinStars, _ = cmd.Flags().GetInt("min-stars")
+	opts.Sort, _ = cmd.Flags().GetString("sort")
+	opts.Limit, _ = cmd.Flags().GetInt("limit")
+	opts.Page, _ = cmd.Flags().GetInt("page")
+
+	switch opts.Sort {
+	case github.SortStars, github.SortUpdated, github.SortBestMatch:
+	default:
+		return opts, fmt.Errorf("unknown sort order: %s (expected stars, updated or best-match)", opts.Sort)
+	}
+	if opts.Limit < 1 || opts.Limit > 100 {
+		return opts, fmt.Errorf("--limit must be between 1 and 100")
+	}
+	if opts.Page < 1 {
+		return opts, fmt.Errorf("--page must be 1 or higher")
+	}
+	if opts.Page*opts.Limit > github.MaxSearchResults+opts.Limit-1 {
+		return opts, fmt.Errorf("GitHub returns only the first %d results; narrow the search down instead", github.MaxSearchResults)
+	}
+
+	since, _ := cmd.Flags().GetString("updated-since")
+	if since != "" {
+		date, err := parseSince(since, time.Now())
+		if err != nil {
+			return opts, err
+		}
+		opts.PushedSince = date
+	}
+	return opts, nil
+}
+
+// parseSince turns a YYYY-MM-DD date or a number of days ago such as 30d
+// into a YYYY-MM-DD date
+func parseSince(value string, now time.Time) (string, error) {
+	if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && strings.HasSuffix(value, "d") && days >= 0 {
+		return now.AddDate(0, 0, -days).Format("2006-01-02"), nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date.Format("2006-01-02"), nil
+	}
+	return "", fmt.Errorf("invalid --updated-since %q (expected YYYY-MM-DD or a number of days such as 30d)", value)
+}
+
+// installedPackages returns the packages installed in the current
+// directory according to its lock file, lowercased, with their locked
+// version. Outside a project it returns an empty map.
+func installedPackages() map[string]string {
+	installed := make(map[string]string)
+	cwd, err := os.Getwd()
+	if err != nil {
+		return installed
+	}
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return installed
+	}
+
+	for name, locked := range lock.Packages {
+		installed[strings.ToLower(name)] = locked.Version
+	}
+	return installed
+}
+
+// otherTopics returns the topics of a package besides the package topic
+func otherTopics(topics []string) []string {
+	var others []string
+	for _, topic := range topics {
+		if topic != github.PackageTopic {
+			others = append(others, topic)
+		}
+	}
+	return others
+}
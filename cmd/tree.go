@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the dependency tree of the project",
+	Long: `Print the packages of skillmaster.json and the packages they depend on,
+with the versions recorded in skillmaster.lock.
+
+A package required by several others is listed in full once and marked as
+deduped afterwards.
+
+Examples:
+  skillmaster tree
+  skillmaster tree --json`,
+	Args: cobra.NoArgs,
+	RunE: runTree,
+}
+
+func init() {
+	treeCmd.Flags().Bool("json", false, "Print the tree as JSON")
+}
+
+// treeNode is a package in the dependency tree
+type treeNode struct {
+	Name         string      `json:"name"`
+	Version      string      `json:"version,omitempty"`    // locked version
+	Constraint   string      `json:"constraint,omitempty"` // version range required by the parent
+	Missing      bool        `json:"missing,omitempty"`    // not in skillmaster.lock
+	Deduped      bool        `json:"deduped,omitempty"`    // dependencies listed at an earlier occurrence
+	Dependencies []*treeNode `json:"dependencies"`
+}
+
+func runTree(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	root := buildTree(m, lock)
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		data, err := json.MarshalIndent(root, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal tree: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	name := root.Name
+	if root.Version != "" {
+		name = fmt.Sprintf("%s@%s", root.Name, root.Version)
+	}
+	color.Cyan(name)
+	if len(root.Dependencies) == 0 {
+		color.Yellow("No packages installed")
+		return nil
+	}
+	printTree(root.Dependencies, "")
+	return nil
+}
+
+// buildTree builds the dependency tree of the manifest from the graph
+// recorded in the lock file
+func buildTree(m *manifest.Manifest, lock *lockfile.LockFile) *treeNode {
+	root := &treeNode{Name: m.Name, Version: m.Version, Dependencies: []*treeNode{}}
+	expanded := make(map[string]bool)
+
+	var build func(name, constraint string) *treeNode
+	build = func(name, constraint string) *treeNode {
+		node := &treeNode{Name: name, Constraint: constraint, Dependencies: []*treeNode{}}
+		locked := lock.Package(name)
+		if locked == nil {
+			node.Missing = true
+			return node
+		}
+		node.Version = locked.Version
+		if expanded[name] {
+			node.Deduped = len(locked.Dependencies) > 0
+			return node
+		}
+		expanded[name] = true
+
+		deps := make([]string, 0, len(locked.Dependencies))
+		for dep := range locked.Dependencies {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			node.Dependencies = append(node.Dependencies, build(dep, locked.Dependencies[dep]))
+		}
+		return node
+	}
+
+	for _, name := range m.DependencyNames() {
+		root.Dependencies = append(root.Dependencies, build(name, m.Dependencies[name]))
+	}
+	return root
+}
+
+// printTree prints tree nodes with box-drawing branches
+func printTree(nodes []*treeNode, prefix string) {
+	faint := color.New(color.Faint)
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		fmt.Print(prefix + branch)
+		switch {
+		case node.Missing:
+			fmt.Printf("%s %s", node.Name, color.RedString("not installed"))
+		default:
+			fmt.Printf("%s@%s", color.CyanString(node.Name), node.Version)
+		}
+		if node.Constraint != "" && node.Constraint != node.Version {
+			faint.Printf(" (%s)", node.Constraint)
+		}
+		if node.Deduped {
+			faint.Print(" deduped")
+		}
+		fmt.Println()
+
+		printTree(node.Dependencies, prefix+indent)
+	}
+}
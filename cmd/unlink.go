@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/link"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink [name]",
+	Short: "Restore the pinned version of a linked package",
+	Long: `Stop using a local package directory.
+
+Run with the name (or owner/repo) of a linked package in a project to
+reinstall the version pinned in skillmaster.lock from GitHub in place of the
+local files.
+
+Run without arguments in a package directory to remove its global
+registration. Projects it is linked into keep their files until they are
+unlinked.
+
+Examples:
+  cd ~/src/app && skillmaster unlink go-style
+  cd ~/src/go-style && skillmaster unlink`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUnlink,
+}
+
+func init() {
+	unlinkCmd.Flags().BoolP("force", "f", false, "Overwrite local changes to the installed files")
+}
+
+func runUnlink(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	registry, err := link.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return unregisterLink(registry, cwd)
+	}
+	return unlinkPackage(cmd, registry, cwd, args[0])
+}
+
+// unregisterLink removes the registration of the package in dir
+func unregisterLink(registry *link.Registry, dir string) error {
+	pkg, err := manifest.LoadPackage(dir)
+	if err != nil {
+		return fmt.Errorf("%w; run skillmaster unlink in a package directory, or skillmaster unlink <name> in a project", err)
+	}
+	if !registry.Remove(pkg.Name) {
+		return fmt.Errorf("%s is not registered", pkg.Name)
+	}
+	if err := registry.Save(); err != nil {
+		return err
+	}
+	color.Green("✓ Unregistered %s", pkg.Name)
+	return nil
+}
+
+// unlinkPackage reinstalls the pinned version of a linked package
+func unlinkPackage(cmd *cobra.Command, registry *link.Registry, cwd, query string) error {
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	repo := query
+	if l := registry.Find(query); l != nil && l.Repo != "" {
+		repo = l.Repo
+	}
+	packageName := dependencyName(m, lock, repo)
+	locked := lock.Package(packageName)
+	if locked == nil || locked.Link == "" {
+		return fmt.Errorf("%s is not linked in this project", query)
+	}
+
+	owner, repoName, err := github.ParseRepoURL(packageName)
+	if err != nil {
+		return err
+	}
+
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	targets, err := lockedTargets(m, cfg, locked)
+	if err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool("force")
+	opts := installOptions{Targets: targets, Force: force, Conflicts: m.Config.Conflicts}
+
+	// Show warning if no GitHub token
+	if cfg.GetGitHubToken() == "" {
+		color.Yellow("⚠ No GitHub token configured. API rate limits will be lower.")
+		color.Blue("ℹ Add a token to ~/.skillmaster/config.json for higher rate limits")
+		fmt.Println()
+	}
+
+	color.Blue("→ Restoring %s@%s...", packageName, locked.Version)
+	inst := installer.New(github.NewClient(cfg.GetGitHubToken()))
+	pkg, err := inst.FetchPackageAt(owner, repoName, locked.Version)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s@%s: %w", packageName, locked.Version, err)
+	}
+	pkg.Options = m.GetDependencyConfig(packageName)
+	printPackageWarnings(packageName, pkg)
+
+	job := &installJob{Name: packageName, Version: locked.Version, Package: pkg, Targets: targets}
+	plans, err := writeJobs([]*installJob{job}, m, lock, cwd, opts)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if plan.Err != nil {
+			return fmt.Errorf("installation into %s failed: %w", plan.Target.Label(), plan.Err)
+		}
+	}
+
+	// Save lock file
+	if err := lock.Save(cwd); err != nil {
+		return err
+	}
+
+	color.Green("✓ Unlinked %s, restored %s", packageName, locked.Version)
+
+	// Refresh composed instruction files
+	if composeOnInstall(cmd, m) {
+		fmt.Println()
+		return composeFiles(cwd, m, configuredComposeFiles(m))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillmaster/pkg/validate"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [dir]",
+	Short: "Check a package before publishing it",
+	Long: `Validate the package in the given directory, or the current one.
+
+Checks that skillmaster-package.json follows the schema, that its version is
+a semantic version and that every files and assets glob matches something.
+The frontmatter of SKILL.md files and Cursor rules (.mdc) is validated, and
+relative links between markdown files must point to files in the package.
+
+Problems are printed as file:line: severity: message. validate exits with a
+non-zero status when there are errors, or warnings with --strict, so it can
+run as a pre-commit hook or in CI.
+
+Examples:
+  skillmaster validate
+  skillmaster validate ./go-style --strict
+  skillmaster validate --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().Bool("strict", false, "Fail on warnings as well as errors")
+	validateCmd.Flags().Bool("json", false, "Print the report as JSON")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	report, err := validate.Run(dir)
+	if err != nil {
+		return err
+	}
+
+	strict, _ := cmd.Flags().GetBool("strict")
+	errorCount := report.Count(validate.SeverityError)
+	warningCount := report.Count(validate.SeverityWarning)
+	failed := errorCount > 0 || (strict && warningCount > 0)
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printValidateReport(report, dir, failed)
+	}
+
+	if failed {
+		// The report already lists the problems
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
+
+// printValidateReport prints the problems with paths relative to the
+// current directory, so editors and terminals can jump to them
+func printValidateReport(report *validate.Report, dir string, failed bool) {
+	for _, problem := range report.Problems {
+		problem.File = filepath.Join(dir, filepath.FromSlash(problem.File))
+		if problem.Severity == validate.SeverityError {
+			color.Red("✗ %s", problem)
+		} else {
+			color.Yellow("⚠ %s", problem)
+		}
+	}
+	if len(report.Problems) > 0 {
+		fmt.Println()
+	}
+
+	errorCount := report.Count(validate.SeverityError)
+	warningCount := report.Count(validate.SeverityWarning)
+	switch {
+	case failed:
+		color.Red("✗ %d error(s), %d warning(s)", errorCount, warningCount)
+	case warningCount > 0:
+		color.Yellow("⚠ Package is valid with %d warning(s); %d file(s) would be installed", warningCount, len(report.Files))
+	default:
+		color.Green("✓ Package is valid; %d file(s) would be installed", len(report.Files))
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/verify"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check installed files against skillmaster.lock",
+	Long: `Verify that the installed packages match skillmaster.lock.
+
+Every installed file is re-hashed and compared with the hash recorded in the
+lock file. Modified, missing and extra files are reported per package, along
+with packages that skillmaster.json and skillmaster.lock disagree about.
+verify exits with a non-zero status when anything differs, so it can be run
+in CI.
+
+Examples:
+  skillmaster verify
+  skillmaster verify --json`,
+	Args: cobra.NoArgs,
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().Bool("json", false, "Print the report as JSON")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	report, err := verify.Run(cwd, m, lock)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printVerifyReport(report)
+	}
+
+	if !report.OK {
+		// The report already describes the drift
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
+
+// printVerifyReport prints a verification report for humans
+func printVerifyReport(report *verify.Report) {
+	fmt.Println()
+	color.Cyan("Verifying installed packages...")
+	fmt.Println()
+
+	drifted := 0
+	for _, pkg := range report.Packages {
+		label := pkg.Name
+		if pkg.Version != "" {
+			label = fmt.Sprintf("%s@%s", pkg.Name, pkg.Version)
+		}
+
+		if pkg.OK() {
+			color.Green("✓ %s (%d files)", label, pkg.Files)
+		} else {
+			drifted++
+			color.Red("✗ %s", label)
+		}
+		for _, filePath := range pkg.Modified {
+			fmt.Printf("    %-9s %s\n", "modified", filePath)
+		}
+		for _, filePath := range pkg.Missing {
+			fmt.Printf("    %-9s %s\n", "missing", filePath)
+		}
+		for _, filePath := range pkg.Extra {
+			fmt.Printf("    %-9s %s\n", "extra", filePath)
+		}
+		for _, issue := range pkg.Issues {
+			color.Yellow("    ⚠ %s", issue)
+		}
+		if len(pkg.Unverified) > 0 {
+			color.Blue("    ℹ %d file(s) were installed before hashes were recorded; reinstall to verify them", len(pkg.Unverified))
+		}
+	}
+
+	for _, filePath := range report.Untracked {
+		color.Yellow("⚠ untracked %s", filePath)
+	}
+	for _, issue := range report.Issues {
+		color.Yellow("⚠ %s", issue)
+	}
+
+	fmt.Println()
+	if report.OK {
+		color.Green("✓ All %d package(s) match skillmaster.lock", len(report.Packages))
+		return
+	}
+	color.Red("✗ Drift detected: %d package(s) differ, %d untracked file(s), %d other issue(s)",
+		drifted, len(report.Untracked), len(report.Issues))
+}
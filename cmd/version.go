@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"skillmaster/pkg/release"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version <major|minor|patch|version>",
+	Short: "Bump the package version and tag the release",
+	Long: `Raise the version in skillmaster-package.json following semantic versioning.
+
+The Unreleased section of CHANGELOG.md, when there is one, becomes the section
+of the new version. The changes are then committed and an annotated vX.Y.Z tag
+is created with the local git. The working tree must be clean.
+
+Examples:
+  skillmaster version patch
+  skillmaster version minor -m "Release %s"
+  skillmaster version 2.0.0
+  skillmaster version major --no-git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersion,
+}
+
+func init() {
+	versionCmd.Flags().StringP("message", "m", "v%s", "Commit and tag message; %s is replaced by the version")
+	versionCmd.Flags().Bool("no-git", false, "Only update the files, without committing or tagging")
+	versionCmd.Flags().Bool("no-changelog", false, "Leave CHANGELOG.md unchanged")
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	message, _ := cmd.Flags().GetString("message")
+	noGit, _ := cmd.Flags().GetBool("no-git")
+	noChangelog, _ := cmd.Flags().GetBool("no-changelog")
+
+	result, err := release.Bump(cwd, release.Options{
+		Part:      args[0],
+		Git:       !noGit,
+		Changelog: !noChangelog,
+		Message:   message,
+	})
+	if err != nil {
+		return err
+	}
+
+	color.Green("✓ Bumped version %s → %s", result.Previous, result.Version)
+	for _, file := range result.Files {
+		fmt.Printf("  %s\n", file)
+	}
+	if result.ChangelogSkipped != "" {
+		color.Yellow("⚠ %s not updated: %s", release.ChangelogFileName, result.ChangelogSkipped)
+	}
+	if result.Tag == "" {
+		return nil
+	}
+
+	color.Green("✓ Committed and tagged %s", result.Tag)
+	color.Blue("ℹ Push the release with: git push --follow-tags")
+	return nil
+}
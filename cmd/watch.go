@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"skillmaster/pkg/config"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/watch"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [owner/repo...]",
+	Short: "Sync linked packages into the project as they change",
+	Long: `Watch the directories of linked packages and install them again
+whenever their files change.
+
+Each sync runs the full install pipeline: the files selected by the
+package's skillmaster-package.json, overrides and patches, every install
+target the package is installed in and, with --compose or compose.onInstall,
+the composed instruction files. Changes are detected by polling and a burst
+of saves is synced once.
+
+Link packages with skillmaster link first. Without arguments every linked
+package is watched. Stop watching with Ctrl+C.
+
+Examples:
+  skillmaster watch
+  skillmaster watch acme/go-style --compose
+  skillmaster watch --interval 1s --debounce 500ms`,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().Duration("interval", watch.DefaultInterval, "Time between checks for changes")
+	watchCmd.Flags().Duration("debounce", watch.DefaultDebounce, "Time files must stay unchanged before a sync")
+	watchCmd.Flags().Bool("compose", false, "Compose AGENTS.md / CLAUDE.md after each sync")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Find the linked packages to watch
+	linked := make(map[string]string)
+	for name, locked := range lock.Packages {
+		if locked.Link != "" {
+			linked[name] = locked.Link
+		}
+	}
+	if len(linked) == 0 {
+		return fmt.Errorf("no linked packages to watch; link one with skillmaster link <name>")
+	}
+	var names []string
+	if len(args) == 0 {
+		for name := range linked {
+			names = append(names, name)
+		}
+	} else {
+		for _, arg := range args {
+			name := dependencyName(m, lock, arg)
+			if _, ok := linked[name]; !ok {
+				return fmt.Errorf("%s is not linked in this project", arg)
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Map each watched directory to its packages
+	packagesByDir := make(map[string][]string)
+	var dirs []string
+	for _, name := range names {
+		dir := linked[name]
+		if _, ok := packagesByDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		packagesByDir[dir] = append(packagesByDir[dir], name)
+	}
+
+	// Load global config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+
+	// Sync once so the project starts from the current files
+	syncLinked(cmd, cfg, cwd, names)
+
+	fmt.Println()
+	color.Cyan("Watching %d linked package(s)...", len(names))
+	for _, name := range names {
+		fmt.Printf("  %s → %s\n", name, linked[name])
+	}
+	color.Blue("ℹ Press Ctrl+C to stop")
+
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	watcher := &watch.Watcher{Dirs: dirs, Interval: interval, Debounce: debounce}
+	watcher.Run(stop, func(changes []watch.Change) {
+		var changed []string
+		fmt.Println()
+		for _, change := range changes {
+			fmt.Printf("[%s] %d file(s) changed in %s: %s\n", time.Now().Format("15:04:05"),
+				len(change.Files), change.Dir, summarizeFiles(change.Files))
+			changed = append(changed, packagesByDir[change.Dir]...)
+		}
+		syncLinked(cmd, cfg, cwd, changed)
+	})
+
+	fmt.Println()
+	color.Blue("ℹ Stopped watching")
+	return nil
+}
+
+// syncLinked installs linked packages from their directories into every
+// target they are installed in. Errors are reported and watching goes on.
+func syncLinked(cmd *cobra.Command, cfg *config.GlobalConfig, cwd string, names []string) {
+	if err := syncLinkedPackages(cmd, cfg, cwd, names); err != nil {
+		color.Red("✗ Sync failed: %v", err)
+	}
+}
+
+func syncLinkedPackages(cmd *cobra.Command, cfg *config.GlobalConfig, cwd string, names []string) error {
+	// Reload the manifest and lock file, which may have changed meanwhile
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	var jobs []*installJob
+	for _, name := range names {
+		locked := lock.Package(name)
+		if locked == nil || locked.Link == "" {
+			color.Yellow("⚠ %s is no longer linked", name)
+			continue
+		}
+		targets, err := lockedTargets(m, cfg, locked)
+		if err != nil {
+			return err
+		}
+		job, err := linkedJob(name, locked.Version, locked.Link, m, targets)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	plans, err := writeJobs(jobs, m, lock, cwd, installOptions{Conflicts: m.Config.Conflicts})
+	if err != nil {
+		return err
+	}
+	failed := false
+	for _, plan := range plans {
+		if plan.Err != nil {
+			color.Red("✗ %s → %s: %v", plan.Job.Name, plan.Target.Label(), plan.Err)
+			failed = true
+			continue
+		}
+		color.Green("✓ Synced %s → %s (%d files)", plan.Job.Name, plan.Target.Label(), plan.Count)
+	}
+
+	// Save lock file
+	if err := lock.Save(cwd); err != nil {
+		return err
+	}
+
+	// Refresh composed instruction files
+	if !failed && composeOnInstall(cmd, m) {
+		return composeFiles(cwd, m, configuredComposeFiles(m))
+	}
+	return nil
+}
+
+// summarizeFiles lists a few changed files
+func summarizeFiles(files []string) string {
+	const shown = 3
+	if len(files) <= shown {
+		return strings.Join(files, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(files[:shown], ", "), len(files)-shown)
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/resolver"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var whyCmd = &cobra.Command{
+	Use:   "why <owner/repo|file>",
+	Short: "Explain why a package or file is installed",
+	Long: `Explain why a package or an installed file is in the project.
+
+For a package, prints the chains of dependencies from skillmaster.json that
+require it and the targets it was installed into. For a file, prints the
+package and target that installed it, and why that package is installed.
+
+Examples:
+  skillmaster why acme/typescript-style
+  skillmaster why .ai/acme-typescript-style/style.md
+  skillmaster why acme/typescript-style --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhy,
+}
+
+func init() {
+	whyCmd.Flags().Bool("json", false, "Print the explanation as JSON")
+}
+
+// whyReport explains why a package or file is installed
+type whyReport struct {
+	Query    string       `json:"query"`
+	Kind     string       `json:"kind"` // package or file
+	Packages []whyPackage `json:"packages"`
+}
+
+// whyPackage is an installed package and the chains that require it
+type whyPackage struct {
+	Name    string                `json:"name"`
+	Version string                `json:"version"`
+	Root    bool                  `json:"root"` // listed in skillmaster.json
+	Chains  [][]whyLink           `json:"chains"`
+	Files   []lockfile.LockedFile `json:"files"` // the queried file, or every file of the package
+}
+
+// whyLink is a package in a chain of dependencies
+type whyLink struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Constraint string `json:"constraint"` // version range required by the previous link
+}
+
+// maxChains bounds the dependency chains listed per package
+const maxChains = 20
+
+func runWhy(cmd *cobra.Command, args []string) error {
+	// Get current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Load manifest
+	m, err := manifest.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	// Load lock file
+	lock, err := lockfile.Load(cwd)
+	if err != nil {
+		return err
+	}
+
+	report, err := explain(args[0], cwd, m, lock)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printWhyReport(report)
+	return nil
+}
+
+// explain looks the query up as a package first, then as an installed file
+func explain(query, cwd string, m *manifest.Manifest, lock *lockfile.LockFile) (*whyReport, error) {
+	if owner, repo, err := github.ParseRepoURL(query); err == nil {
+		name := fmt.Sprintf("%s/%s", owner, repo)
+		if locked := lock.Package(name); locked != nil {
+			pkg := explainPackage(name, m, lock)
+			pkg.Files = append([]lockfile.LockedFile{}, locked.Files...)
+			return &whyReport{Query: query, Kind: "package", Packages: []whyPackage{pkg}}, nil
+		}
+		if _, ok := m.Dependencies[name]; ok {
+			return nil, fmt.Errorf("%s is listed in skillmaster.json but not installed; run skillmaster install", name)
+		}
+	}
+
+	// Paths are relative to the project root
+	filePath := query
+	if filepath.IsAbs(filePath) {
+		rel, err := filepath.Rel(cwd, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", query, err)
+		}
+		filePath = rel
+	}
+	filePath = filepath.ToSlash(filepath.Clean(filePath))
+
+	report := &whyReport{Query: query, Kind: "file", Packages: []whyPackage{}}
+	for _, name := range lock.Owner(filePath) {
+		pkg := explainPackage(name, m, lock)
+		for _, file := range lock.Package(name).Files {
+			if file.Path == filePath {
+				pkg.Files = append(pkg.Files, file)
+			}
+		}
+		report.Packages = append(report.Packages, pkg)
+	}
+	if len(report.Packages) == 0 {
+		return nil, fmt.Errorf("%s is neither an installed package nor a file installed by one", query)
+	}
+	return report, nil
+}
+
+// explainPackage finds the chains of dependencies from skillmaster.json
+// to a package, following the graph recorded in the lock file
+func explainPackage(name string, m *manifest.Manifest, lock *lockfile.LockFile) whyPackage {
+	pkg := whyPackage{Name: name, Version: lock.Package(name).Version, Chains: [][]whyLink{}, Files: []lockfile.LockedFile{}}
+	_, pkg.Root = m.Dependencies[name]
+
+	onPath := make(map[string]bool)
+	var chain []whyLink
+	var walk func(current, constraint string)
+	walk = func(current, constraint string) {
+		if len(pkg.Chains) == maxChains || onPath[current] {
+			return
+		}
+		locked := lock.Package(current)
+		if locked == nil {
+			return
+		}
+
+		chain = append(chain, whyLink{Name: current, Version: locked.Version, Constraint: constraint})
+		onPath[current] = true
+		if current == name {
+			pkg.Chains = append(pkg.Chains, append([]whyLink{}, chain...))
+		} else {
+			deps := make([]string, 0, len(locked.Dependencies))
+			for dep := range locked.Dependencies {
+				deps = append(deps, dep)
+			}
+			sort.Strings(deps)
+			for _, dep := range deps {
+				walk(dep, locked.Dependencies[dep])
+			}
+		}
+		onPath[current] = false
+		chain = chain[:len(chain)-1]
+	}
+
+	for _, root := range m.DependencyNames() {
+		walk(root, m.Dependencies[root])
+	}
+	return pkg
+}
+
+// printWhyReport prints an explanation for humans
+func printWhyReport(report *whyReport) {
+	faint := color.New(color.Faint)
+	for i, pkg := range report.Packages {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		if report.Kind == "file" {
+			for _, file := range pkg.Files {
+				fmt.Printf("%s installed by %s\n", color.CyanString(file.Path), color.CyanString("%s@%s", pkg.Name, pkg.Version))
+				faint.Printf("  %s\n", describeLockedFile(file))
+			}
+		} else {
+			color.Cyan("%s@%s", pkg.Name, pkg.Version)
+		}
+
+		// Why the package is installed
+		switch {
+		case len(pkg.Chains) > 0:
+			fmt.Println("  required by:")
+			for _, chain := range pkg.Chains {
+				links := []string{resolver.RootRequirer}
+				for _, link := range chain {
+					links = append(links, fmt.Sprintf("%s@%s (%s)", link.Name, link.Version, link.Constraint))
+				}
+				fmt.Printf("    %s\n", strings.Join(links, " → "))
+			}
+			if len(pkg.Chains) == maxChains {
+				faint.Printf("    ... only the first %d chains are shown\n", maxChains)
+			}
+		default:
+			color.Yellow("  ⚠ no package of skillmaster.json requires %s anymore", pkg.Name)
+		}
+
+		// Where the package was installed
+		if report.Kind == "package" {
+			fmt.Println("  installed into:")
+			for _, line := range summarizeTargets(pkg.Files) {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+}
+
+// describeLockedFile describes the target and source of an installed file
+func describeLockedFile(file lockfile.LockedFile) string {
+	desc := lockedTargetLabel(file)
+	if file.Source != "" {
+		desc += ", from " + file.Source
+	}
+	if file.Section != "" {
+		desc += fmt.Sprintf(", section %s", file.Section)
+	}
+	return desc
+}
+
+// lockedTargetLabel names the target and profile an installed file belongs to
+func lockedTargetLabel(file lockfile.LockedFile) string {
+	if file.Profile == "" {
+		return fmt.Sprintf("%s target, default install directory", file.Target)
+	}
+	return fmt.Sprintf("%s target, profile %s", file.Target, file.Profile)
+}
+
+// summarizeTargets counts the installed files per profile and target
+func summarizeTargets(files []lockfile.LockedFile) []string {
+	counts := make(map[string]int)
+	var labels []string
+	for _, file := range files {
+		label := lockedTargetLabel(file)
+		if counts[label] == 0 {
+			labels = append(labels, label)
+		}
+		counts[label]++
+	}
+	sort.Strings(labels)
+
+	lines := make([]string, 0, len(labels))
+	for _, label := range labels {
+		lines = append(lines, fmt.Sprintf("%s: %d file(s)", label, counts[label]))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "no files")
+	}
+	return lines
+}
@@ -0,0 +1,182 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/glob"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+)
+
+// SectionID identifies the managed block in a composed file
+const SectionID = "compose"
+
+// DefaultFile is composed when the manifest does not configure any files
+var DefaultFile = manifest.ComposeFile{Path: "AGENTS.md", Mode: manifest.ComposeInline}
+
+// Entry is an installed package file selected for a composed file
+type Entry struct {
+	Package string
+	Source  string // path inside the package
+	Path    string // installed path, slash-separated and relative to the project root
+}
+
+// Select returns the installed files to compose, in the configured order.
+// Packages are taken in the order listed in the compose file (or all
+// installed packages, dependencies included, sorted by name), and files
+// within a package in the order of the include patterns. Packages listed in
+// the compose file must be installed; dependencies missing from the lock
+// file are skipped with a warning.
+func Select(file manifest.ComposeFile, m *manifest.Manifest, lock *lockfile.LockFile) ([]Entry, []string, error) {
+	packages := file.Packages
+	explicit := len(packages) > 0
+	if !explicit {
+		for name := range m.Dependencies {
+			packages = append(packages, name)
+		}
+		for name, locked := range lock.Packages {
+			if _, ok := m.Dependencies[name]; !ok && locked.Transitive {
+				packages = append(packages, name)
+			}
+		}
+		sort.Strings(packages)
+	}
+
+	var entries []Entry
+	var warnings []string
+	for _, name := range packages {
+		locked := lock.Package(name)
+		if locked == nil {
+			if explicit {
+				return nil, nil, fmt.Errorf("package %s is not installed", name)
+			}
+			warnings = append(warnings, fmt.Sprintf("%s is not installed; run skillmaster install", name))
+			continue
+		}
+
+		var selected []Entry
+		rank := make(map[string]int)
+		for _, f := range locked.FilesFor("") {
+			if f.Target != installer.TargetDefault || f.Section != "" {
+				continue
+			}
+			if i, ok := includeRank(file.Include, f.Source); ok {
+				rank[f.Source] = i
+				selected = append(selected, Entry{Package: name, Source: f.Source, Path: f.Path})
+			}
+		}
+		sort.SliceStable(selected, func(i, j int) bool {
+			if rank[selected[i].Source] != rank[selected[j].Source] {
+				return rank[selected[i].Source] < rank[selected[j].Source]
+			}
+			return selected[i].Source < selected[j].Source
+		})
+		entries = append(entries, selected...)
+	}
+
+	return entries, warnings, nil
+}
+
+// includeRank returns the index of the first include pattern matching
+// source. Without patterns every instruction markdown file is included.
+func includeRank(include []string, source string) (int, bool) {
+	if len(include) == 0 {
+		lower := strings.ToLower(source)
+		return 0, strings.HasSuffix(lower, ".md") && !installer.IsPackageDoc(source)
+	}
+	for i, pattern := range include {
+		if glob.Match(pattern, source) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Render builds the content of the managed block
+func Render(file manifest.ComposeFile, entries []Entry, projectDir string) ([]byte, error) {
+	var buf bytes.Buffer
+	composedDir := path.Dir(filepath.ToSlash(file.Path))
+
+	currentPackage := ""
+	for _, entry := range entries {
+		if entry.Package != currentPackage {
+			if currentPackage != "" {
+				buf.WriteString("\n")
+			}
+			currentPackage = entry.Package
+			if file.Mode != manifest.ComposeInline {
+				fmt.Fprintf(&buf, "## %s\n\n", entry.Package)
+			}
+		}
+
+		switch file.Mode {
+		case manifest.ComposeInline:
+			content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(entry.Path)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", entry.Path, err)
+			}
+			_, body, err := frontmatter.Parse(content)
+			if err != nil {
+				body = content
+			}
+			fmt.Fprintf(&buf, "<!-- %s: %s -->\n", entry.Package, entry.Source)
+			buf.Write(bytes.TrimSpace(body))
+			buf.WriteString("\n\n")
+
+		case "", manifest.ComposeImport:
+			fmt.Fprintf(&buf, "@%s\n", relativePath(composedDir, entry.Path))
+
+		default:
+			return nil, fmt.Errorf("unknown compose mode: %s (expected %s or %s)", file.Mode, manifest.ComposeImport, manifest.ComposeInline)
+		}
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// Write updates the managed block of a composed file, creating the file
+// from its template when it does not exist. Content outside the block is
+// left untouched. An empty block is removed.
+func Write(file manifest.ComposeFile, content []byte, projectDir string) error {
+	outputPath := filepath.Join(projectDir, filepath.FromSlash(file.Path))
+
+	if len(content) == 0 {
+		return installer.RemoveSection(outputPath, SectionID)
+	}
+
+	if _, err := os.Stat(outputPath); os.IsNotExist(err) && file.Template != "" {
+		template, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(file.Template)))
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(outputPath, template, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+	}
+
+	return installer.WriteSection(outputPath, SectionID, content)
+}
+
+// relativePath returns target relative to dir, both slash-separated
+// and relative to the project root
+func relativePath(dir, target string) string {
+	if dir == "." || dir == "" {
+		return target
+	}
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+	return filepath.ToSlash(rel)
+}
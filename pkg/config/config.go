@@ -12,10 +12,19 @@ type GitHubConfig struct {
 	Token string `json:"token"`
 }
 
+// Profile describes where a specific AI assistant expects its files
+// and the layout (install target) it expects them in
+type Profile struct {
+	InstallDir string `json:"installDir"`
+	Target     string `json:"target,omitempty"`
+	Compose    bool   `json:"compose,omitempty"`
+}
+
 // GlobalConfig represents the global configuration file
 type GlobalConfig struct {
-	GitHub     GitHubConfig `json:"github"`
-	InstallDir string       `json:"installDir"`
+	GitHub     GitHubConfig       `json:"github"`
+	InstallDir string             `json:"installDir"`
+	Profiles   map[string]Profile `json:"profiles,omitempty"`
 }
 
 const (
@@ -23,6 +32,15 @@ const (
 	ConfigFileName = "config.json"
 )
 
+// DefaultProfiles returns the built-in assistant profiles
+func DefaultProfiles() map[string]Profile {
+	return map[string]Profile{
+		"claude":  {InstallDir: ".claude", Target: "claude-skills"},
+		"cursor":  {InstallDir: ".cursor/rules", Target: "cursor"},
+		"copilot": {InstallDir: ".github", Target: "copilot"},
+	}
+}
+
 // GetConfigPath returns the path to the global config file
 // It first checks the current directory for .skillmaster/config.json
 // and falls back to the home directory if not found
@@ -60,6 +78,7 @@ func Load() (*GlobalConfig, error) {
 				Token: "",
 			},
 			InstallDir: ".ai",
+			Profiles:   DefaultProfiles(),
 		}, nil
 	}
 
@@ -78,6 +97,16 @@ func Load() (*GlobalConfig, error) {
 		config.InstallDir = ".ai"
 	}
 
+	// Built-in profiles are available unless the user redefines them
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]Profile)
+	}
+	for name, profile := range DefaultProfiles() {
+		if _, ok := config.Profiles[name]; !ok {
+			config.Profiles[name] = profile
+		}
+	}
+
 	return &config, nil
 }
 
@@ -111,6 +140,12 @@ func (c *GlobalConfig) GetGitHubToken() string {
 	return c.GitHub.Token
 }
 
+// GetProfile returns the named profile from the config
+func (c *GlobalConfig) GetProfile(name string) (Profile, bool) {
+	profile, ok := c.Profiles[name]
+	return profile, ok
+}
+
 // InitializeConfig creates a default config file if it doesn't exist
 func InitializeConfig() error {
 	configPath, err := GetConfigPath()
@@ -128,6 +163,7 @@ func InitializeConfig() error {
 			Token: "",
 		},
 		InstallDir: ".ai",
+		Profiles:   DefaultProfiles(),
 	}
 
 	return config.Save()
@@ -0,0 +1,351 @@
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const delimiter = "---"
+
+// Field is a single key/value pair in a frontmatter block.
+// Value is either a string or a []string.
+type Field struct {
+	Key   string
+	Value interface{}
+	Line  int
+}
+
+// Frontmatter is the YAML header of a markdown file. Only the subset of
+// YAML used by assistant files is supported: scalars, inline lists,
+// block lists and folded/literal block scalars.
+type Frontmatter struct {
+	fields []Field
+}
+
+// SyntaxError is returned for frontmatter that cannot be parsed
+type SyntaxError struct {
+	Line int // 1-based line number in the file
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// New creates an empty frontmatter block
+func New() *Frontmatter {
+	return &Frontmatter{}
+}
+
+// Parse splits a markdown file into its frontmatter and body.
+// Files without frontmatter return an empty Frontmatter and the full content as body.
+func Parse(content []byte) (*Frontmatter, []byte, error) {
+	fm := New()
+
+	text := string(content)
+	text = strings.TrimPrefix(text, "\ufeff")
+	if !strings.HasPrefix(text, delimiter+"\n") && !strings.HasPrefix(text, delimiter+"\r\n") {
+		return fm, content, nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == delimiter {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return nil, nil, &SyntaxError{Line: 1, Msg: fmt.Sprintf("frontmatter is not terminated with '%s'", delimiter)}
+	}
+
+	if err := fm.parseLines(lines[1:end], 2); err != nil {
+		return nil, nil, err
+	}
+
+	body := strings.Join(lines[end+1:], "")
+	return fm, []byte(body), nil
+}
+
+// parseLines parses the lines between the delimiters.
+// firstLine is the 1-based line number of lines[0] in the file.
+func (f *Frontmatter) parseLines(lines []string, firstLine int) error {
+	for i := 0; i < len(lines); i++ {
+		lineNo := firstLine + i
+		line := strings.TrimRight(lines[i], "\r\n")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return &SyntaxError{Line: lineNo, Msg: "unexpected indentation"}
+		}
+
+		colon := strings.Index(line, ":")
+		if colon <= 0 {
+			return &SyntaxError{Line: lineNo, Msg: "expected 'key: value'"}
+		}
+		key := strings.TrimSpace(line[:colon])
+		raw := strings.TrimSpace(line[colon+1:])
+
+		switch {
+		case raw == "|" || raw == ">" || raw == "|-" || raw == ">-":
+			// Block scalar: collect indented continuation lines
+			var block []string
+			for i+1 < len(lines) && isIndentedOrBlank(lines[i+1]) {
+				i++
+				block = append(block, strings.TrimSpace(strings.TrimRight(lines[i], "\r\n")))
+			}
+			sep := "\n"
+			if raw[0] == '>' {
+				sep = " "
+			}
+			f.fields = append(f.fields, Field{Key: key, Value: strings.TrimSpace(strings.Join(block, sep)), Line: lineNo})
+
+		case raw == "":
+			// Either an empty value or a block list
+			var items []string
+			for i+1 < len(lines) && isListItem(lines[i+1]) {
+				i++
+				item := strings.TrimPrefix(strings.TrimSpace(lines[i]), "- ")
+				items = append(items, unquote(strings.TrimSpace(item)))
+			}
+			if items != nil {
+				f.fields = append(f.fields, Field{Key: key, Value: items, Line: lineNo})
+			} else {
+				f.fields = append(f.fields, Field{Key: key, Value: "", Line: lineNo})
+			}
+
+		case strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]"):
+			// Inline list
+			inner := strings.TrimSpace(raw[1 : len(raw)-1])
+			items := []string{}
+			if inner != "" {
+				for _, item := range splitList(inner) {
+					items = append(items, unquote(strings.TrimSpace(item)))
+				}
+			}
+			f.fields = append(f.fields, Field{Key: key, Value: items, Line: lineNo})
+
+		default:
+			f.fields = append(f.fields, Field{Key: key, Value: unquote(stripComment(raw)), Line: lineNo})
+		}
+	}
+
+	return nil
+}
+
+// Fields returns all fields in their original order
+func (f *Frontmatter) Fields() []Field {
+	return f.fields
+}
+
+// Len returns the number of fields
+func (f *Frontmatter) Len() int {
+	return len(f.fields)
+}
+
+// Has reports whether the key is present
+func (f *Frontmatter) Has(key string) bool {
+	_, ok := f.lookup(key)
+	return ok
+}
+
+// Line returns the 1-based line number of a key, or 0 if it is not present
+func (f *Frontmatter) Line(key string) int {
+	if i, ok := f.lookup(key); ok {
+		return f.fields[i].Line
+	}
+	return 0
+}
+
+// String returns the value of key as a string. Lists are joined with ", ".
+func (f *Frontmatter) String(key string) string {
+	i, ok := f.lookup(key)
+	if !ok {
+		return ""
+	}
+	switch v := f.fields[i].Value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return ""
+}
+
+// Strings returns the value of key as a list. Scalars are split on commas.
+func (f *Frontmatter) Strings(key string) []string {
+	i, ok := f.lookup(key)
+	if !ok {
+		return nil
+	}
+	switch v := f.fields[i].Value.(type) {
+	case []string:
+		return v
+	case string:
+		var items []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// Bool returns the value of key as a boolean and whether it was set to a valid boolean
+func (f *Frontmatter) Bool(key string) (bool, bool) {
+	i, ok := f.lookup(key)
+	if !ok {
+		return false, false
+	}
+	s, ok := f.fields[i].Value.(string)
+	if !ok {
+		return false, false
+	}
+	b, err := strconv.ParseBool(strings.ToLower(s))
+	if err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// Set adds or replaces a field. Value must be a string, bool or []string.
+func (f *Frontmatter) Set(key string, value interface{}) {
+	if b, ok := value.(bool); ok {
+		value = strconv.FormatBool(b)
+	}
+	if i, ok := f.lookup(key); ok {
+		f.fields[i].Value = value
+		return
+	}
+	f.fields = append(f.fields, Field{Key: key, Value: value})
+}
+
+// Delete removes a field
+func (f *Frontmatter) Delete(key string) {
+	if i, ok := f.lookup(key); ok {
+		f.fields = append(f.fields[:i], f.fields[i+1:]...)
+	}
+}
+
+// Render writes the frontmatter followed by body.
+// Keys listed in order are written first, in that order.
+func Render(f *Frontmatter, body []byte, order ...string) []byte {
+	var buf bytes.Buffer
+	if f != nil && f.Len() > 0 {
+		buf.WriteString(delimiter + "\n")
+		written := make(map[string]bool)
+		for _, key := range order {
+			if i, ok := f.lookup(key); ok {
+				writeField(&buf, f.fields[i])
+				written[key] = true
+			}
+		}
+		for _, field := range f.fields {
+			if !written[field.Key] {
+				writeField(&buf, field)
+			}
+		}
+		buf.WriteString(delimiter + "\n")
+	}
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func writeField(buf *bytes.Buffer, field Field) {
+	switch v := field.Value.(type) {
+	case []string:
+		quoted := make([]string, len(v))
+		for i, item := range v {
+			quoted[i] = strconv.Quote(item)
+		}
+		fmt.Fprintf(buf, "%s: [%s]\n", field.Key, strings.Join(quoted, ", "))
+	case string:
+		fmt.Fprintf(buf, "%s: %s\n", field.Key, quoteIfNeeded(v))
+	}
+}
+
+func (f *Frontmatter) lookup(key string) (int, bool) {
+	for i, field := range f.fields {
+		if field.Key == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// quoteIfNeeded quotes scalars that would otherwise be read back differently
+func quoteIfNeeded(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if s == "true" || s == "false" {
+		return s
+	}
+	if strings.ContainsAny(s, ":#\n\"'") || strings.ContainsAny(s[:1], "[]{}>|*&!%@`-?, ") || strings.HasSuffix(s, " ") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 {
+		if s[0] == '"' && s[len(s)-1] == '"' {
+			if u, err := strconv.Unquote(s); err == nil {
+				return u
+			}
+			return s[1 : len(s)-1]
+		}
+		if s[0] == '\'' && s[len(s)-1] == '\'' {
+			return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+		}
+	}
+	return s
+}
+
+// stripComment removes a trailing " # comment" from an unquoted scalar
+func stripComment(s string) string {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		return s
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
+
+// splitList splits an inline list on commas that are not inside quotes
+func splitList(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+func isListItem(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " \t"), "- ")
+}
+
+func isIndentedOrBlank(line string) bool {
+	return strings.TrimSpace(line) == "" || line[0] == ' ' || line[0] == '\t'
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Repo runs the local git binary in a working tree
+type Repo struct {
+	dir string
+}
+
+// Open returns the git working tree containing dir
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git is not installed or not in PATH")
+	}
+	r := &Repo{dir: dir}
+	if _, err := r.run("rev-parse", "--show-toplevel"); err != nil {
+		return nil, fmt.Errorf("%s is not inside a git repository", dir)
+	}
+	return r, nil
+}
+
+// run executes a git command and returns its trimmed output
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Changes returns the modified and staged files of the working tree as
+// reported by git status; untracked files are ignored
+func (r *Repo) Changes() ([]string, error) {
+	out, err := r.run("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// UntrackedFiles returns the files of the working tree below dir that git
+// does not track and does not ignore, relative to dir
+func (r *Repo) UntrackedFiles() ([]string, error) {
+	out, err := r.run("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// CommitOf returns the hash of the commit a revision such as HEAD or a tag
+// points to
+func (r *Repo) CommitOf(rev string) (string, error) {
+	return r.run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+}
+
+// TagExists reports whether a tag exists
+func (r *Repo) TagExists(name string) (bool, error) {
+	out, err := r.run("tag", "--list", name)
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// Commit stages the given paths and commits them
+func (r *Repo) Commit(message string, paths ...string) error {
+	if _, err := r.run(append([]string{"add", "--"}, paths...)...); err != nil {
+		return err
+	}
+	_, err := r.run(append([]string{"commit", "-m", message, "--"}, paths...)...)
+	return err
+}
+
+// RemoteURL returns the URL of a remote
+func (r *Repo) RemoteURL(remote string) (string, error) {
+	return r.run("remote", "get-url", remote)
+}
+
+// PushTag pushes a tag to a remote
+func (r *Repo) PushTag(remote, tag string) error {
+	_, err := r.run("push", remote, "refs/tags/"+tag)
+	return err
+}
+
+// Unstage removes the given paths from the index, keeping the working tree
+func (r *Repo) Unstage(paths ...string) error {
+	_, err := r.run(append([]string{"reset", "-q", "--"}, paths...)...)
+	return err
+}
+
+// Tag creates an annotated tag on the current commit
+func (r *Repo) Tag(name, message string) error {
+	_, err := r.run("tag", "-a", name, "-m", message)
+	return err
+}
@@ -2,7 +2,9 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"path"
 	"strings"
 
@@ -10,6 +12,9 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// ErrNotFound is returned when a requested file does not exist
+var ErrNotFound = errors.New("not found")
+
 // Client wraps the GitHub API client
 type Client struct {
 	client *github.Client
@@ -23,7 +28,11 @@ type RepositoryInfo struct {
 	Description string
 	Stars       int
 	UpdatedAt   string
+	PushedAt    string // date of the last push
 	DefaultBranch string
+	License     string // SPDX identifier, empty when GitHub detected none
+	Language    string
+	Topics      []string
 }
 
 // FileContent represents a file downloaded from GitHub
@@ -53,6 +62,22 @@ func NewClient(token string) *Client {
 	}
 }
 
+// NewClientWithBaseURL creates a client for another API endpoint, such as
+// GitHub Enterprise or a test server. Uploads go to the same endpoint.
+func NewClientWithBaseURL(token, baseURL string) (*Client, error) {
+	c := NewClient(token)
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL %s: %w", baseURL, err)
+	}
+	c.client.BaseURL = u
+	c.client.UploadURL = u
+	return c, nil
+}
+
 // GetRepository fetches repository information
 func (c *Client) GetRepository(owner, repo string) (*RepositoryInfo, error) {
 	repository, resp, err := c.client.Repositories.Get(c.ctx, owner, repo)
@@ -78,6 +103,17 @@ func (c *Client) GetRepository(owner, repo string) (*RepositoryInfo, error) {
 	if repository.UpdatedAt != nil {
 		info.UpdatedAt = repository.UpdatedAt.Format("2006-01-02")
 	}
+	if repository.PushedAt != nil {
+		info.PushedAt = repository.PushedAt.Format("2006-01-02")
+	}
+	if repository.License != nil {
+		info.License = repository.License.GetSPDXID()
+		if info.License == "" || info.License == "NOASSERTION" {
+			info.License = repository.License.GetName()
+		}
+	}
+	info.Language = repository.GetLanguage()
+	info.Topics = repository.Topics
 
 	return info, nil
 }
@@ -111,24 +147,62 @@ func (c *Client) GetLatestVersion(owner, repo string) (string, error) {
 	return "main", nil
 }
 
+// ListTags returns the names of all tags of a repository
+func (c *Client) ListTags(owner, repo string) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		tags, resp, err := c.client.Repositories.ListTags(c.ctx, owner, repo, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				return nil, fmt.Errorf("repository not found: %s/%s", owner, repo)
+			}
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		for _, tag := range tags {
+			if tag.Name != nil {
+				names = append(names, *tag.Name)
+			}
+		}
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // DownloadMarkdownFiles recursively downloads all markdown files from a repository
 func (c *Client) DownloadMarkdownFiles(owner, repo, ref string) ([]FileContent, error) {
+	return c.DownloadFiles(owner, repo, ref, func(filePath string, size int) bool {
+		return strings.HasSuffix(strings.ToLower(filePath), ".md")
+	})
+}
+
+// DownloadFiles recursively downloads the files of a repository that include
+// accepts. include is given the size of each file before it is downloaded.
+func (c *Client) DownloadFiles(owner, repo, ref string, include func(filePath string, size int) bool) ([]FileContent, error) {
 	var files []FileContent
-	
-	err := c.walkRepositoryTree(owner, repo, ref, "", &files)
+
+	err := c.walkRepositoryTree(owner, repo, ref, "", include, &files)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(files) == 0 {
-		return nil, fmt.Errorf("no markdown files found in repository")
+		return nil, fmt.Errorf("no installable files found in repository")
 	}
 
 	return files, nil
 }
 
+// GetFile downloads a single file. It returns an error wrapping ErrNotFound
+// when the file does not exist.
+func (c *Client) GetFile(owner, repo, ref, filePath string) ([]byte, error) {
+	return c.downloadFile(owner, repo, ref, filePath)
+}
+
 // walkRepositoryTree recursively walks through the repository tree
-func (c *Client) walkRepositoryTree(owner, repo, ref, dirPath string, files *[]FileContent) error {
+func (c *Client) walkRepositoryTree(owner, repo, ref, dirPath string, include func(string, int) bool, files *[]FileContent) error {
 	_, contents, resp, err := c.client.Repositories.GetContents(c.ctx, owner, repo, dirPath, &github.RepositoryContentGetOptions{
 		Ref: ref,
 	})
@@ -154,8 +228,7 @@ func (c *Client) walkRepositoryTree(owner, repo, ref, dirPath string, files *[]F
 		}
 
 		if contentType == "file" {
-			// Only process markdown files
-			if strings.HasSuffix(strings.ToLower(contentPath), ".md") {
+			if include(contentPath, content.GetSize()) {
 				fileContent, err := c.downloadFile(owner, repo, ref, contentPath)
 				if err != nil {
 					return fmt.Errorf("failed to download file %s: %w", contentPath, err)
@@ -167,7 +240,7 @@ func (c *Client) walkRepositoryTree(owner, repo, ref, dirPath string, files *[]F
 			}
 		} else if contentType == "dir" {
 			// Recursively walk subdirectories
-			if err := c.walkRepositoryTree(owner, repo, ref, contentPath, files); err != nil {
+			if err := c.walkRepositoryTree(owner, repo, ref, contentPath, include, files); err != nil {
 				return err
 			}
 		}
@@ -184,7 +257,7 @@ func (c *Client) downloadFile(owner, repo, ref, filePath string) ([]byte, error)
 	
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
-			return nil, fmt.Errorf("file not found: %s", filePath)
+			return nil, fmt.Errorf("file %w: %s", ErrNotFound, filePath)
 		}
 		return nil, fmt.Errorf("failed to get file content: %w", err)
 	}
@@ -201,50 +274,6 @@ func (c *Client) downloadFile(owner, repo, ref, filePath string) ([]byte, error)
 	return []byte(content), nil
 }
 
-// SearchRepositories searches for repositories by topic
-func (c *Client) SearchRepositories(query string, limit int) ([]*RepositoryInfo, error) {
-	// Build search query with required topic
-	searchQuery := fmt.Sprintf("topic:skillmaster-package %s", query)
-	
-	opts := &github.SearchOptions{
-		Sort:  "stars",
-		Order: "desc",
-		ListOptions: github.ListOptions{
-			PerPage: limit,
-		},
-	}
-
-	result, resp, err := c.client.Search.Repositories(c.ctx, searchQuery, opts)
-	if err != nil {
-		if resp != nil && resp.StatusCode == 403 {
-			return nil, fmt.Errorf("GitHub API rate limit exceeded. Please add a GitHub token to ~/.skillmaster/config.json")
-		}
-		return nil, fmt.Errorf("failed to search repositories: %w", err)
-	}
-
-	var repos []*RepositoryInfo
-	for _, repo := range result.Repositories {
-		info := &RepositoryInfo{
-			Owner: repo.GetOwner().GetLogin(),
-			Name:  repo.GetName(),
-		}
-
-		if repo.Description != nil {
-			info.Description = *repo.Description
-		}
-		if repo.StargazersCount != nil {
-			info.Stars = *repo.StargazersCount
-		}
-		if repo.UpdatedAt != nil {
-			info.UpdatedAt = repo.UpdatedAt.Format("2006-01-02")
-		}
-
-		repos = append(repos, info)
-	}
-
-	return repos, nil
-}
-
 // ParseRepoURL parses a repository URL in the format "owner/repo"
 func ParseRepoURL(repoURL string) (owner, repo string, err error) {
 	parts := strings.Split(repoURL, "/")
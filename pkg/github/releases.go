@@ -0,0 +1,135 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Release is a GitHub release
+type Release struct {
+	ID          int64
+	TagName     string
+	Name        string
+	URL         string // web page of the release
+	Prerelease  bool
+	PublishedAt string // YYYY-MM-DD, empty for drafts
+}
+
+// ReleaseOptions describes a release to create
+type ReleaseOptions struct {
+	TagName string
+	Name    string
+	// Body is the release notes; when empty, GitHub generates them from the
+	// pull requests and commits since the previous release
+	Body       string
+	Prerelease bool
+}
+
+// GetReleaseByTag returns the release of a tag. It returns an error
+// wrapping ErrNotFound when the tag has no release.
+func (c *Client) GetReleaseByTag(owner, repo, tag string) (*Release, error) {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(c.ctx, owner, repo, tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, fmt.Errorf("release %s: %w", tag, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to fetch release %s: %w", tag, err)
+	}
+	return toRelease(release), nil
+}
+
+// ListReleases returns the published releases of a repository, newest first
+func (c *Client) ListReleases(owner, repo string) ([]*Release, error) {
+	var releases []*Release
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.client.Repositories.ListReleases(c.ctx, owner, repo, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				return nil, fmt.Errorf("repository not found: %s/%s", owner, repo)
+			}
+			return nil, fmt.Errorf("failed to list releases: %w", err)
+		}
+		for _, release := range page {
+			if !release.GetDraft() {
+				releases = append(releases, toRelease(release))
+			}
+		}
+		if resp.NextPage == 0 {
+			return releases, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateRelease creates a release for an existing tag
+func (c *Client) CreateRelease(owner, repo string, opts ReleaseOptions) (*Release, error) {
+	request := &github.RepositoryRelease{
+		TagName:    github.String(opts.TagName),
+		Name:       github.String(opts.Name),
+		Prerelease: github.Bool(opts.Prerelease),
+	}
+	if opts.Body != "" {
+		request.Body = github.String(opts.Body)
+	} else {
+		request.GenerateReleaseNotes = github.Bool(true)
+	}
+
+	release, resp, err := c.client.Repositories.CreateRelease(c.ctx, owner, repo, request)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, fmt.Errorf("repository not found or not writable with the configured token: %s/%s", owner, repo)
+		}
+		return nil, fmt.Errorf("failed to create release: %w", err)
+	}
+	return toRelease(release), nil
+}
+
+// UploadReleaseAsset attaches a file to a release
+func (c *Client) UploadReleaseAsset(owner, repo string, releaseID int64, name, mediaType string, content []byte) error {
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?name=%s", owner, repo, releaseID, url.QueryEscape(name))
+	req, err := c.client.NewUploadRequest(u, bytes.NewReader(content), int64(len(content)), mediaType)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	if _, err := c.client.Do(c.ctx, req, new(github.ReleaseAsset)); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	return nil
+}
+
+// EnsureTopic adds a topic to a repository unless it is already set.
+// It reports whether the topic was added.
+func (c *Client) EnsureTopic(owner, repo, topic string) (bool, error) {
+	topics, _, err := c.client.Repositories.ListAllTopics(c.ctx, owner, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch topics: %w", err)
+	}
+	for _, t := range topics {
+		if t == topic {
+			return false, nil
+		}
+	}
+
+	if _, _, err := c.client.Repositories.ReplaceAllTopics(c.ctx, owner, repo, append(topics, topic)); err != nil {
+		return false, fmt.Errorf("failed to add topic %s: %w", topic, err)
+	}
+	return true, nil
+}
+
+func toRelease(release *github.RepositoryRelease) *Release {
+	r := &Release{
+		ID:         release.GetID(),
+		TagName:    release.GetTagName(),
+		Name:       release.GetName(),
+		URL:        release.GetHTMLURL(),
+		Prerelease: release.GetPrerelease(),
+	}
+	if release.PublishedAt != nil {
+		r.PublishedAt = release.PublishedAt.Format("2006-01-02")
+	}
+	return r
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// RepositoryFile is a file in a repository tree
+type RepositoryFile struct {
+	Path string
+	Size int
+}
+
+// GetReadme downloads the README of a repository at a tag or branch. An
+// empty ref selects the default branch. It returns the README's path and
+// content, or an error wrapping ErrNotFound when there is none.
+func (c *Client) GetReadme(owner, repo, ref string) (string, []byte, error) {
+	readme, resp, err := c.client.Repositories.GetReadme(c.ctx, owner, repo, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return "", nil, fmt.Errorf("README %w", ErrNotFound)
+		}
+		return "", nil, fmt.Errorf("failed to fetch README: %w", err)
+	}
+
+	content, err := readme.GetContent()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode README: %w", err)
+	}
+	return readme.GetPath(), []byte(content), nil
+}
+
+// ListFiles returns the files of a repository at a tag or branch with their
+// sizes in a single request. Hidden files and directories are skipped, as
+// when downloading a package.
+func (c *Client) ListFiles(owner, repo, ref string) ([]RepositoryFile, error) {
+	tree, resp, err := c.client.Git.GetTree(c.ctx, owner, repo, ref, true)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, fmt.Errorf("ref not found: %s/%s@%s", owner, repo, ref)
+		}
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("repository %s/%s has too many files to list", owner, repo)
+	}
+
+	var files []RepositoryFile
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" || hiddenPath(entry.GetPath()) {
+			continue
+		}
+		files = append(files, RepositoryFile{Path: entry.GetPath(), Size: entry.GetSize()})
+	}
+	return files, nil
+}
+
+// hiddenPath reports whether a file or one of its directories is hidden
+func hiddenPath(filePath string) bool {
+	for _, part := range strings.Split(filePath, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
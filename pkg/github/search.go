@@ -0,0 +1,129 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// PackageTopic is the GitHub topic that marks a repository as a package
+const PackageTopic = "skillmaster-package"
+
+// Search result orders
+const (
+	SortStars     = "stars"
+	SortUpdated   = "updated"
+	SortBestMatch = "best-match"
+)
+
+// MaxSearchResults is the number of results GitHub returns for a search
+// across all pages
+const MaxSearchResults = 1000
+
+// SearchOptions filters, orders and pages a package search
+type SearchOptions struct {
+	Query       string   // keywords
+	Topics      []string // topics the repository must have besides the package topic
+	Language    string
+	Owner       string // user or organization
+	MinStars    int
+	PushedSince string // YYYY-MM-DD; the last push must be on or after it
+	Sort        string // stars (default), updated or best-match
+	Limit       int    // results per page, at most 100
+	Page        int    // 1-based
+}
+
+// SearchResult is a page of search results
+type SearchResult struct {
+	Total        int // matching repositories across all pages
+	Repositories []*RepositoryInfo
+}
+
+// SearchQuery returns the GitHub search query for the options
+func (o SearchOptions) SearchQuery() string {
+	terms := []string{"topic:" + PackageTopic}
+	for _, topic := range o.Topics {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			terms = append(terms, "topic:"+topic)
+		}
+	}
+	if o.Language != "" {
+		terms = append(terms, "language:"+quoteQualifier(o.Language))
+	}
+	if o.Owner != "" {
+		terms = append(terms, "user:"+o.Owner)
+	}
+	if o.MinStars > 0 {
+		terms = append(terms, fmt.Sprintf("stars:>=%d", o.MinStars))
+	}
+	if o.PushedSince != "" {
+		terms = append(terms, "pushed:>="+o.PushedSince)
+	}
+	if query := strings.TrimSpace(o.Query); query != "" {
+		terms = append(terms, query)
+	}
+	return strings.Join(terms, " ")
+}
+
+// SearchPackages searches the repositories with the package topic
+func (c *Client) SearchPackages(opts SearchOptions) (*SearchResult, error) {
+	searchOpts := &github.SearchOptions{
+		ListOptions: github.ListOptions{
+			PerPage: opts.Limit,
+			Page:    opts.Page,
+		},
+	}
+	switch opts.Sort {
+	case "", SortStars:
+		searchOpts.Sort, searchOpts.Order = "stars", "desc"
+	case SortUpdated:
+		searchOpts.Sort, searchOpts.Order = "updated", "desc"
+	case SortBestMatch:
+	default:
+		return nil, fmt.Errorf("unknown sort order: %s (expected stars, updated or best-match)", opts.Sort)
+	}
+
+	result, resp, err := c.client.Search.Repositories(c.ctx, opts.SearchQuery(), searchOpts)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, fmt.Errorf("GitHub API rate limit exceeded. Please add a GitHub token to ~/.skillmaster/config.json")
+		}
+		if resp != nil && resp.StatusCode == 422 {
+			return nil, fmt.Errorf("invalid search: %w", err)
+		}
+		return nil, fmt.Errorf("failed to search repositories: %w", err)
+	}
+
+	search := &SearchResult{Total: result.GetTotal()}
+	for _, repo := range result.Repositories {
+		info := &RepositoryInfo{
+			Owner:         repo.GetOwner().GetLogin(),
+			Name:          repo.GetName(),
+			Description:   repo.GetDescription(),
+			Stars:         repo.GetStargazersCount(),
+			DefaultBranch: repo.GetDefaultBranch(),
+			Language:      repo.GetLanguage(),
+			Topics:        repo.Topics,
+		}
+		if repo.UpdatedAt != nil {
+			info.UpdatedAt = repo.UpdatedAt.Format("2006-01-02")
+		}
+		if repo.PushedAt != nil {
+			info.PushedAt = repo.PushedAt.Format("2006-01-02")
+		}
+		if repo.License != nil {
+			info.License = repo.License.GetSPDXID()
+		}
+		search.Repositories = append(search.Repositories, info)
+	}
+	return search, nil
+}
+
+// quoteQualifier quotes a qualifier value containing spaces
+func quoteQualifier(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
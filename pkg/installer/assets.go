@@ -0,0 +1,92 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
+)
+
+// Size limits for the non-markdown files a package declares as assets
+const (
+	MaxAssetSize   = 256 * 1024      // per file
+	MaxAssetsTotal = 2 * 1024 * 1024 // per package
+)
+
+// assetExtensions lists the file types a package may install as assets:
+// assistant configuration, data files, example code and scripts
+var assetExtensions = map[string]bool{
+	".mdc": true, ".mdx": true, ".txt": true, ".prompt": true,
+	".yaml": true, ".yml": true, ".json": true, ".jsonc": true, ".toml": true, ".xml": true, ".csv": true,
+	".sh": true, ".bash": true, ".zsh": true, ".fish": true, ".ps1": true,
+	".py": true, ".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".jsx": true, ".tsx": true,
+	".go": true, ".rb": true, ".rs": true, ".java": true, ".kt": true, ".swift": true,
+	".c": true, ".h": true, ".cpp": true, ".cs": true, ".php": true, ".lua": true, ".sql": true,
+	".html": true, ".css": true, ".scss": true,
+}
+
+// AllowedAsset reports whether a file type may be installed as an asset
+func AllowedAsset(filePath string) bool {
+	return assetExtensions[strings.ToLower(path.Ext(filePath))]
+}
+
+// fileSelector decides which repository files of a package are downloaded:
+// markdown files selected by the package metadata, plus the assets it
+// declares within the allowlist and size limits. Skipped assets are
+// recorded as warnings.
+type fileSelector struct {
+	manifest *manifest.PackageManifest
+	total    int
+	warnings []string
+}
+
+func (s *fileSelector) include(filePath string, size int) bool {
+	if isMarkdown(filePath) {
+		return s.manifest == nil || s.manifest.Includes(filePath)
+	}
+	if s.manifest == nil || !s.manifest.IncludesAsset(filePath) {
+		return false
+	}
+
+	switch {
+	case !AllowedAsset(filePath):
+		s.warnings = append(s.warnings, fmt.Sprintf("skipped asset %s: file type not allowed", filePath))
+		return false
+	case size > MaxAssetSize:
+		s.warnings = append(s.warnings, fmt.Sprintf("skipped asset %s: %s exceeds the %s limit", filePath, FormatSize(size), FormatSize(MaxAssetSize)))
+		return false
+	case s.total+size > MaxAssetsTotal:
+		s.warnings = append(s.warnings, fmt.Sprintf("skipped asset %s: assets exceed the %s package limit", filePath, FormatSize(MaxAssetsTotal)))
+		return false
+	}
+
+	s.total += size
+	return true
+}
+
+// SelectFiles returns the files of a repository listing that a package
+// installs, selected as FetchPackageAt selects them, and warnings for the
+// declared assets it skips
+func SelectFiles(pkg *manifest.PackageManifest, files []github.RepositoryFile) ([]github.RepositoryFile, []string) {
+	selector := &fileSelector{manifest: pkg}
+	var selected []github.RepositoryFile
+	for _, file := range files {
+		if selector.include(file.Path, file.Size) {
+			selected = append(selected, file)
+		}
+	}
+	return selected, selector.warnings
+}
+
+// FormatSize formats a byte count for messages
+func FormatSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%d KB", size/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
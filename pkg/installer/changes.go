@@ -0,0 +1,139 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/lockfile"
+)
+
+// Kinds of local changes to installed files
+const (
+	ChangeModified = "modified"
+	ChangeDeleted  = "deleted"
+	ChangeAdded    = "added"
+)
+
+// Change is a difference between the project and the files recorded in
+// the lock file
+type Change struct {
+	Kind string
+	Path string              // slash-separated, relative to the project root
+	File lockfile.LockedFile // the locked file; only the path is set for added files
+}
+
+// FileHash returns the hash recorded in the lock file for an output file.
+// Section files are hashed by the content of their section.
+func FileHash(file OutputFile) string {
+	if file.Section != "" {
+		return lockfile.Hash(sectionBody(file.Content))
+	}
+	return lockfile.Hash(file.Content)
+}
+
+// DetectChanges compares installed files with the hashes recorded in the
+// lock file. Files installed before hashes were recorded are only checked
+// for deletion. Files the lock file does not know are reported as added
+// when they are in a directory owned by the package: its owner-repo
+// directory or the directory of one of its skills.
+func DetectChanges(projectDir, namespace string, lock *lockfile.LockFile, files []lockfile.LockedFile) ([]Change, error) {
+	var changes []Change
+	owned := make(map[string]bool)
+
+	for _, file := range files {
+		fullPath := filepath.Join(projectDir, filepath.FromSlash(file.Path))
+
+		var content []byte
+		var exists bool
+		if file.Section != "" {
+			section, found, err := ReadSection(fullPath, file.Section)
+			if err != nil {
+				return nil, err
+			}
+			content, exists = section, found
+		} else {
+			data, err := os.ReadFile(fullPath)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+			content, exists = data, err == nil
+
+			if dir := ownedDir(file.Path, namespace); dir != "" {
+				owned[dir] = true
+			}
+		}
+
+		switch {
+		case !exists:
+			changes = append(changes, Change{Kind: ChangeDeleted, Path: file.Path, File: file})
+		case file.Hash != "" && lockfile.Hash(content) != file.Hash:
+			changes = append(changes, Change{Kind: ChangeModified, Path: file.Path, File: file})
+		}
+	}
+
+	// Untracked files in directories owned by the package
+	tracked := make(map[string]bool)
+	for _, pkg := range lock.Packages {
+		for _, file := range pkg.Files {
+			tracked[file.Path] = true
+		}
+	}
+	var dirs []string
+	for dir := range owned {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		root := filepath.Join(projectDir, filepath.FromSlash(dir))
+		err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(projectDir, filePath)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if !tracked[rel] && !seen[rel] {
+				seen[rel] = true
+				changes = append(changes, Change{Kind: ChangeAdded, Path: rel, File: lockfile.LockedFile{Path: rel}})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// ownedDir returns the directory of a package that contains the file: the
+// owner-repo directory of the separate strategy or a skill directory
+func ownedDir(filePath, namespace string) string {
+	parts := strings.Split(filePath, "/")
+	for i, part := range parts[:len(parts)-1] {
+		if part == namespace {
+			return strings.Join(parts[:i+1], "/")
+		}
+	}
+	if path.Base(filePath) == skillFileName {
+		return path.Dir(filePath)
+	}
+	return ""
+}
@@ -0,0 +1,251 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/github"
+)
+
+const (
+	skillFileName      = "SKILL.md"
+	maxSkillNameLength = 64
+)
+
+// MaxSkillDescriptionSize is the longest SKILL.md description in characters;
+// longer ones are truncated on install
+const MaxSkillDescriptionSize = 1024
+
+var (
+	validSkillName   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// claudeSkillsTarget maps a package into .claude/skills/<name>/ folders.
+// Every directory containing a SKILL.md becomes a skill; a package without
+// any SKILL.md is installed as a single skill built from its README.
+type claudeSkillsTarget struct{}
+
+func (claudeSkillsTarget) Name() string {
+	return TargetClaudeSkills
+}
+
+func (claudeSkillsTarget) Files(pkg *Package) ([]OutputFile, error) {
+	// Find skill directories
+	skillFiles := make(map[string]github.FileContent)
+	var skillDirs []string
+	for _, file := range pkg.Files {
+		if strings.EqualFold(path.Base(file.Path), skillFileName) {
+			dir := path.Dir(file.Path)
+			skillFiles[dir] = file
+			skillDirs = append(skillDirs, dir)
+		}
+	}
+
+	if len(skillDirs) == 0 {
+		return packageAsSkill(pkg)
+	}
+
+	// Deepest directories first so nested skills claim their own files
+	sort.SliceStable(skillDirs, func(i, j int) bool {
+		return dirDepth(skillDirs[i]) > dirDepth(skillDirs[j])
+	})
+
+	var files []OutputFile
+	names := make(map[string]string)
+	skillNames := make(map[string]string)
+	for _, dir := range skillDirs {
+		fallback := path.Base(dir)
+		if dir == "." {
+			fallback = pkg.Repo
+		}
+
+		file := skillFiles[dir]
+		content, name, err := normalizeSkill(file.Content, fallback, pkg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid skill %s: %w", file.Path, err)
+		}
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("skills %s and %s both use the name %q", other, dir, name)
+		}
+		names[name] = dir
+		skillNames[dir] = name
+		files = append(files, OutputFile{
+			Path:    path.Join("skills", name, skillFileName),
+			Content: content,
+			Source:  file.Path,
+		})
+	}
+
+	// Copy supporting files alongside the skill that contains them
+	for _, file := range pkg.Files {
+		if strings.EqualFold(path.Base(file.Path), skillFileName) {
+			continue
+		}
+		for _, dir := range skillDirs {
+			rel, ok := relativeTo(file.Path, dir)
+			if !ok {
+				continue
+			}
+			files = append(files, OutputFile{
+				Path:    path.Join("skills", skillNames[dir], rel),
+				Content: file.Content,
+				Source:  file.Path,
+			})
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// packageAsSkill installs a package without SKILL.md files as one skill,
+// generating SKILL.md from the README and copying the other files alongside
+func packageAsSkill(pkg *Package) ([]OutputFile, error) {
+	var readme []byte
+	readmePath := ""
+	for _, file := range pkg.Files {
+		if strings.EqualFold(file.Path, "README.md") {
+			readme = file.Content
+			readmePath = file.Path
+			break
+		}
+	}
+	if readme == nil {
+		readme = []byte(fmt.Sprintf("# %s\n\nSupporting files:\n\n%s", pkg.Repo, fileIndex(pkg)))
+	}
+
+	content, name, err := normalizeSkill(readme, pkg.Repo, pkg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s: %w", skillFileName, err)
+	}
+
+	files := []OutputFile{{
+		Path:    path.Join("skills", name, skillFileName),
+		Content: content,
+		Source:  readmePath,
+	}}
+	for _, file := range pkg.Files {
+		if file.Path == readmePath {
+			continue
+		}
+		files = append(files, OutputFile{
+			Path:    path.Join("skills", name, file.Path),
+			Content: file.Content,
+			Source:  file.Path,
+		})
+	}
+
+	return files, nil
+}
+
+// normalizeSkill validates the SKILL.md frontmatter, deriving a name and
+// description when they are missing, and returns the rewritten file
+func normalizeSkill(content []byte, fallbackName string, pkg *Package) ([]byte, string, error) {
+	fm, body, err := frontmatter.Parse(content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	name := fm.String("name")
+	if name == "" {
+		name = fallbackName
+	}
+	name = SkillName(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("cannot derive a valid skill name")
+	}
+	fm.Set("name", name)
+
+	description := strings.TrimSpace(fm.String("description"))
+	if description == "" {
+		description = firstParagraph(body)
+	}
+	if description == "" {
+		description = pkg.Description
+	}
+	if description == "" {
+		description = fmt.Sprintf("Skill from %s/%s", pkg.Owner, pkg.Repo)
+	}
+	if utf8.RuneCountInString(description) > MaxSkillDescriptionSize {
+		description = strings.TrimSpace(string([]rune(description)[:MaxSkillDescriptionSize-3])) + "..."
+	}
+	fm.Set("description", description)
+
+	return frontmatter.Render(fm, body, "name", "description"), name, nil
+}
+
+// SkillName converts a string into a valid skill name:
+// lowercase letters, digits and hyphens, at most 64 characters
+func SkillName(s string) string {
+	if validSkillName.MatchString(s) && len(s) <= maxSkillNameLength {
+		return s
+	}
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(s), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > maxSkillNameLength {
+		name = strings.TrimRight(name[:maxSkillNameLength], "-")
+	}
+	return name
+}
+
+// firstParagraph returns the first paragraph of markdown text, skipping headings
+func firstParagraph(body []byte) string {
+	var paragraph []string
+	inCode := false
+	for _, line := range strings.Split(string(body), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "<!--") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		if trimmed == "" {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, trimmed)
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// fileIndex lists the files of a package as markdown links
+func fileIndex(pkg *Package) string {
+	var buf bytes.Buffer
+	for _, file := range pkg.Files {
+		fmt.Fprintf(&buf, "- [%s](%s)\n", file.Path, file.Path)
+	}
+	return buf.String()
+}
+
+// dirDepth returns the nesting depth of a slash-separated directory, -1 for the root
+func dirDepth(dir string) int {
+	if dir == "." {
+		return -1
+	}
+	return strings.Count(dir, "/")
+}
+
+// relativeTo returns p relative to dir if p is inside dir
+func relativeTo(p, dir string) (string, bool) {
+	if dir == "." {
+		return p, true
+	}
+	if strings.HasPrefix(p, dir+"/") {
+		return strings.TrimPrefix(p, dir+"/"), true
+	}
+	return "", false
+}
@@ -0,0 +1,180 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Conflict resolution policies
+const (
+	ConflictFail        = "fail"        // abort the installation
+	ConflictFirstWins   = "first-wins"  // the package listed first in the manifest keeps the path
+	ConflictLastWins    = "last-wins"   // the package listed last in the manifest keeps the path
+	ConflictInteractive = "interactive" // ask which package keeps the path
+	ConflictRename      = "rename"      // keep the first package's file and rename the others
+)
+
+// Claim is a package's claim on an output path, relative to the project root
+type Claim struct {
+	Package   string
+	Path      string
+	Installed bool // the file is already on disk from an earlier installation
+}
+
+// Conflict is an output path claimed by more than one package
+type Conflict struct {
+	Path     string
+	Packages []string // in manifest order
+	// Installed lists the packages whose file is already on disk
+	Installed []string
+}
+
+// Resolution decides which package keeps a conflicting path
+type Resolution struct {
+	Winner string
+	Rename bool // the other packages write to a renamed path instead of being skipped
+}
+
+// ValidConflictPolicy reports whether s is a known conflict policy
+func ValidConflictPolicy(s string) bool {
+	switch s {
+	case ConflictFail, ConflictFirstWins, ConflictLastWins, ConflictInteractive, ConflictRename:
+		return true
+	}
+	return false
+}
+
+// DetectConflicts returns the paths claimed by more than one package.
+// order is the manifest order used to sort the packages of a conflict.
+func DetectConflicts(claims []Claim, order []string) []Conflict {
+	rank := make(map[string]int)
+	for i, name := range order {
+		rank[name] = i
+	}
+
+	byPath := make(map[string]*Conflict)
+	var paths []string
+	for _, claim := range claims {
+		conflict, ok := byPath[claim.Path]
+		if !ok {
+			conflict = &Conflict{Path: claim.Path}
+			byPath[claim.Path] = conflict
+			paths = append(paths, claim.Path)
+		}
+		if !contains(conflict.Packages, claim.Package) {
+			conflict.Packages = append(conflict.Packages, claim.Package)
+		}
+		if claim.Installed && !contains(conflict.Installed, claim.Package) {
+			conflict.Installed = append(conflict.Installed, claim.Package)
+		}
+	}
+
+	sort.Strings(paths)
+	var conflicts []Conflict
+	for _, p := range paths {
+		conflict := byPath[p]
+		if len(conflict.Packages) < 2 {
+			continue
+		}
+		sort.SliceStable(conflict.Packages, func(i, j int) bool {
+			return rankOf(rank, conflict.Packages[i]) < rankOf(rank, conflict.Packages[j])
+		})
+		conflicts = append(conflicts, *conflict)
+	}
+	return conflicts
+}
+
+// ResolveConflicts applies a policy to every conflict. choose is called for
+// the interactive policy and returns the winning package, or "" to rename.
+func ResolveConflicts(conflicts []Conflict, policy string, choose func(Conflict) (string, error)) (map[string]Resolution, error) {
+	if policy == "" {
+		policy = ConflictFail
+	}
+	if !ValidConflictPolicy(policy) {
+		return nil, fmt.Errorf("unknown conflict policy: %s", policy)
+	}
+
+	if policy == ConflictFail && len(conflicts) > 0 {
+		var lines []string
+		for _, conflict := range conflicts {
+			lines = append(lines, fmt.Sprintf("  %s: %s", conflict.Path, strings.Join(conflict.Packages, ", ")))
+		}
+		return nil, fmt.Errorf("%d file conflict(s) between packages:\n%s\npass --conflicts or set config.conflicts in skillmaster.json to first-wins, last-wins, interactive or rename to resolve them",
+			len(conflicts), strings.Join(lines, "\n"))
+	}
+
+	resolutions := make(map[string]Resolution)
+	for _, conflict := range conflicts {
+		switch policy {
+		case ConflictFirstWins:
+			resolutions[conflict.Path] = Resolution{Winner: conflict.Packages[0]}
+		case ConflictLastWins:
+			resolutions[conflict.Path] = Resolution{Winner: conflict.Packages[len(conflict.Packages)-1]}
+		case ConflictRename:
+			resolutions[conflict.Path] = Resolution{Winner: renameWinner(conflict), Rename: true}
+		case ConflictInteractive:
+			winner, err := choose(conflict)
+			if err != nil {
+				return nil, err
+			}
+			if winner == "" {
+				resolutions[conflict.Path] = Resolution{Winner: renameWinner(conflict), Rename: true}
+			} else {
+				resolutions[conflict.Path] = Resolution{Winner: winner}
+			}
+		}
+	}
+	return resolutions, nil
+}
+
+// RenamedPath returns the path a losing package writes to under the rename
+// policy: the package namespace is prefixed to the file name
+func RenamedPath(filePath, owner, repo string) string {
+	dir, name := path.Split(filePath)
+	return path.Join(dir, fmt.Sprintf("%s-%s-%s", owner, repo, name))
+}
+
+// UniquePath returns filePath, or when taken reports it as used, the first
+// free path with a counter before the extension: name-2.md, name-3.md, ...
+func UniquePath(filePath string, taken func(string) bool) string {
+	if !taken(filePath) {
+		return filePath
+	}
+	ext := path.Ext(filePath)
+	base := strings.TrimSuffix(filePath, ext)
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// renameWinner keeps an already installed file in place so that renaming
+// never moves files of packages that are not being installed
+func renameWinner(conflict Conflict) string {
+	for _, name := range conflict.Packages {
+		if contains(conflict.Installed, name) {
+			return name
+		}
+	}
+	return conflict.Packages[0]
+}
+
+func rankOf(rank map[string]int, name string) int {
+	if i, ok := rank[name]; ok {
+		return i
+	}
+	return len(rank)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/manifest"
+)
+
+const (
+	copilotInstructionsDir  = "instructions"
+	copilotInstructionsFile = "copilot-instructions.md"
+	copilotApplyToAll       = "**"
+)
+
+// copilotTarget converts markdown files into GitHub Copilot instruction files
+// under instructions/*.instructions.md with applyTo frontmatter. With compose
+// enabled, repository-wide instructions are written as this package's section
+// of copilot-instructions.md instead.
+type copilotTarget struct {
+	compose bool
+}
+
+func (copilotTarget) Name() string {
+	return TargetCopilot
+}
+
+func (t copilotTarget) Files(pkg *Package) ([]OutputFile, error) {
+	var files []OutputFile
+	var composed bytes.Buffer
+	var composedSources []string
+
+	for _, file := range pkg.Files {
+		if !isMarkdown(file.Path) || IsPackageDoc(file.Path) {
+			continue
+		}
+
+		fm, body, err := frontmatter.Parse(file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frontmatter in %s: %w", file.Path, err)
+		}
+
+		applyTo := copilotApplyTo(pkg, file.Path, fm)
+		body = bytes.TrimLeft(body, "\n")
+
+		if t.compose && applyTo == copilotApplyToAll {
+			if composed.Len() > 0 {
+				composed.WriteString("\n")
+			}
+			composed.Write(body)
+			if !bytes.HasSuffix(body, []byte("\n")) {
+				composed.WriteString("\n")
+			}
+			composedSources = append(composedSources, file.Path)
+			continue
+		}
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "---\napplyTo: %s\n---\n", strconv.Quote(applyTo))
+		buf.Write(body)
+		files = append(files, OutputFile{
+			Path:    path.Join(copilotInstructionsDir, strings.TrimSuffix(ruleFileName(pkg, file.Path, ".md"), ".md")+".instructions.md"),
+			Content: buf.Bytes(),
+			Source:  file.Path,
+		})
+	}
+
+	if composed.Len() > 0 {
+		files = append(files, OutputFile{
+			Path:    copilotInstructionsFile,
+			Content: composed.Bytes(),
+			Source:  strings.Join(composedSources, ","),
+			Section: fmt.Sprintf("%s/%s", pkg.Owner, pkg.Repo),
+		})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("package has no markdown files to convert into Copilot instructions")
+	}
+
+	return files, nil
+}
+
+// copilotApplyTo resolves the applyTo glob for a file. The consumer's
+// manifest overrides win over the defaults in the package's metadata, which
+// win over the file's own applyTo or globs frontmatter. At each level
+// per-file globs win over package-wide ones; files without any apply to the
+// whole repository.
+func copilotApplyTo(pkg *Package, filePath string, fm *frontmatter.Frontmatter) string {
+	applyTo := strings.Join(fm.Strings("applyTo"), ",")
+	if applyTo == "" {
+		applyTo = strings.Join(fm.Strings("globs"), ",")
+	}
+
+	if pkg.Manifest != nil {
+		applyTo = applyCopilotOptions(applyTo, pkg.Manifest.Copilot, filePath)
+	}
+	applyTo = applyCopilotOptions(applyTo, pkg.Options.Copilot, filePath)
+
+	if applyTo == "" {
+		applyTo = copilotApplyToAll
+	}
+	return applyTo
+}
+
+// applyCopilotOptions overrides applyTo with the package-wide and per-file
+// globs of opts
+func applyCopilotOptions(applyTo string, opts *manifest.CopilotOptions, filePath string) string {
+	if opts == nil {
+		return applyTo
+	}
+	if opts.ApplyTo != "" {
+		applyTo = opts.ApplyTo
+	}
+	if fileApplyTo, ok := opts.Files[filePath]; ok && fileApplyTo != "" {
+		applyTo = fileApplyTo
+	}
+	return applyTo
+}
@@ -0,0 +1,158 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/manifest"
+)
+
+// cursorTarget converts each markdown file into a Cursor .mdc rule and
+// copies .mdc rules the package ships as assets.
+// Rules are written flat as owner-repo-<path>.mdc so they can be
+// attributed to their package and removed with it.
+type cursorTarget struct{}
+
+func (cursorTarget) Name() string {
+	return TargetCursor
+}
+
+func (cursorTarget) Files(pkg *Package) ([]OutputFile, error) {
+	var files []OutputFile
+	for _, file := range pkg.Files {
+		// Packages may ship ready-made Cursor rules as assets
+		if strings.EqualFold(path.Ext(file.Path), ".mdc") {
+			files = append(files, OutputFile{
+				Path:    ruleFileName(pkg, file.Path, ".mdc"),
+				Content: file.Content,
+				Source:  file.Path,
+			})
+			continue
+		}
+		if !isMarkdown(file.Path) || IsPackageDoc(file.Path) {
+			continue
+		}
+
+		fm, body, err := frontmatter.Parse(file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frontmatter in %s: %w", file.Path, err)
+		}
+
+		rule := cursorRule(pkg, file.Path, fm, body)
+		files = append(files, OutputFile{
+			Path:    ruleFileName(pkg, file.Path, ".mdc"),
+			Content: renderCursorRule(rule, body),
+			Source:  file.Path,
+		})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("package has no markdown files or .mdc rules to convert into Cursor rules")
+	}
+
+	return files, nil
+}
+
+// cursorRule resolves the rule settings for a file. The consumer's manifest
+// overrides win over the defaults in the package's metadata, which win over
+// the file's own frontmatter. At each level per-file settings win over
+// package-wide ones.
+func cursorRule(pkg *Package, filePath string, fm *frontmatter.Frontmatter, body []byte) manifest.CursorRule {
+	rule := manifest.CursorRule{
+		Description: fm.String("description"),
+		Globs:       fm.Strings("globs"),
+	}
+	if alwaysApply, ok := fm.Bool("alwaysApply"); ok {
+		rule.AlwaysApply = &alwaysApply
+	}
+
+	if pkg.Manifest != nil {
+		rule = applyCursorOptions(rule, pkg.Manifest.Cursor, filePath)
+	}
+	rule = applyCursorOptions(rule, pkg.Options.Cursor, filePath)
+
+	if rule.Description == "" {
+		rule.Description = firstParagraph(body)
+	}
+	if rule.Description == "" {
+		rule.Description = fmt.Sprintf("%s from %s/%s", filePath, pkg.Owner, pkg.Repo)
+	}
+
+	return rule
+}
+
+// applyCursorOptions merges the package-wide and per-file settings of opts
+// into rule
+func applyCursorOptions(rule manifest.CursorRule, opts *manifest.CursorOptions, filePath string) manifest.CursorRule {
+	if opts == nil {
+		return rule
+	}
+	rule = mergeCursorRule(rule, opts.CursorRule)
+	if fileRule, ok := opts.Files[filePath]; ok {
+		rule = mergeCursorRule(rule, fileRule)
+	}
+	return rule
+}
+
+func mergeCursorRule(base, override manifest.CursorRule) manifest.CursorRule {
+	if override.Description != "" {
+		base.Description = override.Description
+	}
+	if override.Globs != nil {
+		base.Globs = override.Globs
+	}
+	if override.AlwaysApply != nil {
+		base.AlwaysApply = override.AlwaysApply
+	}
+	return base
+}
+
+// renderCursorRule writes the .mdc frontmatter in the exact form Cursor
+// expects: globs are a bare comma-separated list rather than YAML.
+func renderCursorRule(rule manifest.CursorRule, body []byte) []byte {
+	alwaysApply := rule.AlwaysApply != nil && *rule.AlwaysApply
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	fmt.Fprintf(&buf, "description: %s\n", strconv.Quote(singleLine(rule.Description)))
+	if len(rule.Globs) > 0 {
+		fmt.Fprintf(&buf, "globs: %s\n", strings.Join(rule.Globs, ","))
+	} else {
+		buf.WriteString("globs:\n")
+	}
+	fmt.Fprintf(&buf, "alwaysApply: %t\n", alwaysApply)
+	buf.WriteString("---\n")
+	buf.Write(bytes.TrimLeft(body, "\n"))
+	return buf.Bytes()
+}
+
+// ruleFileName flattens a package path into a single file name
+// prefixed with the package namespace
+func ruleFileName(pkg *Package, filePath, ext string) string {
+	name := strings.TrimSuffix(filePath, path.Ext(filePath))
+	name = strings.ReplaceAll(name, "/", "-")
+	return fmt.Sprintf("%s-%s%s", pkg.Namespace(), name, ext)
+}
+
+// isMarkdown reports whether a package file is a markdown file
+func isMarkdown(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), ".md")
+}
+
+// IsPackageDoc reports whether a file documents the package itself
+// rather than instructing the assistant
+func IsPackageDoc(filePath string) bool {
+	switch strings.ToUpper(filePath) {
+	case "README.MD", "CHANGELOG.MD", "LICENSE.MD", "CONTRIBUTING.MD":
+		return true
+	}
+	return false
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,63 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
+)
+
+// DependencySource reads package versions and the dependencies declared in
+// skillmaster-package.json from GitHub, for the dependency resolver
+type DependencySource struct {
+	githubClient *github.Client
+}
+
+// NewDependencySource creates a DependencySource
+func NewDependencySource(githubClient *github.Client) *DependencySource {
+	return &DependencySource{githubClient: githubClient}
+}
+
+// Versions returns the tags of a package
+func (s *DependencySource) Versions(name string) ([]string, error) {
+	owner, repo, err := github.ParseRepoURL(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.githubClient.ListTags(owner, repo)
+}
+
+// DefaultRef returns the default branch of a package
+func (s *DependencySource) DefaultRef(name string) (string, error) {
+	owner, repo, err := github.ParseRepoURL(name)
+	if err != nil {
+		return "", err
+	}
+	info, err := s.githubClient.GetRepository(owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return info.DefaultBranch, nil
+}
+
+// Dependencies returns the dependencies declared by a package version
+func (s *DependencySource) Dependencies(name, version string) (map[string]string, error) {
+	owner, repo, err := github.ParseRepoURL(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.githubClient.GetFile(owner, repo, version, manifest.PackageFileName)
+	if errors.Is(err, github.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pkgManifest, err := manifest.ParsePackage(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s@%s: %w", name, version, err)
+	}
+	return pkgManifest.Dependencies, nil
+}
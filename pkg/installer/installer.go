@@ -1,12 +1,13 @@
 package installer
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
 )
 
 // Installer handles package installation
@@ -21,51 +22,93 @@ func New(githubClient *github.Client) *Installer {
 	}
 }
 
-// InstallPackage installs a package from GitHub to the specified directory
-func (i *Installer) InstallPackage(owner, repo, installDir string) (int, error) {
+// Package is a package fetched from GitHub that is ready to be written
+type Package struct {
+	Owner       string
+	Repo        string
+	Ref         string
+	Description string
+	Files       []github.FileContent
+	Options     manifest.DependencyConfig
+	Manifest    *manifest.PackageManifest // nil when the package has no skillmaster-package.json
+	Warnings    []string                  // declared assets that were not installed
+}
+
+// Namespace returns the directory name used for the package: owner-repo
+func (p *Package) Namespace() string {
+	return fmt.Sprintf("%s-%s", p.Owner, p.Repo)
+}
+
+// FetchPackage downloads the installable files of a package from GitHub
+func (i *Installer) FetchPackage(owner, repo string) (*Package, error) {
+	return i.FetchPackageAt(owner, repo, "")
+}
+
+// FetchPackageAt downloads the installable files of a package at a tag or
+// branch. An empty ref selects the default branch.
+func (i *Installer) FetchPackageAt(owner, repo, ref string) (*Package, error) {
 	// Get repository information
 	repoInfo, err := i.githubClient.GetRepository(owner, repo)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Get the reference to download from (default branch or latest tag)
-	ref := repoInfo.DefaultBranch
+	if ref == "" {
+		ref = repoInfo.DefaultBranch
+	}
 
-	// Download all markdown files
-	files, err := i.githubClient.DownloadMarkdownFiles(owner, repo, ref)
-	if err != nil {
-		return 0, err
+	// Read the package metadata, if the package has any
+	var pkgManifest *manifest.PackageManifest
+	data, err := i.githubClient.GetFile(owner, repo, ref, manifest.PackageFileName)
+	if err == nil {
+		if pkgManifest, err = manifest.ParsePackage(data); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, github.ErrNotFound) {
+		return nil, err
 	}
 
-	// Create namespaced directory: installDir/owner-repo/
-	namespace := fmt.Sprintf("%s-%s", owner, repo)
-	targetDir := filepath.Join(installDir, namespace)
+	// Download the markdown files and assets selected by the metadata
+	selector := &fileSelector{manifest: pkgManifest}
+	files, err := i.githubClient.DownloadFiles(owner, repo, ref, selector.include)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create target directory
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return 0, fmt.Errorf("failed to create installation directory: %w", err)
+	description := repoInfo.Description
+	if pkgManifest != nil && pkgManifest.Description != "" {
+		description = pkgManifest.Description
 	}
 
-	// Copy files maintaining directory structure
-	fileCount := 0
-	for _, file := range files {
-		targetPath := filepath.Join(targetDir, file.Path)
-		
-		// Create parent directories
-		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
-			return fileCount, fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
-		}
+	return &Package{
+		Owner:       owner,
+		Repo:        repo,
+		Ref:         ref,
+		Description: description,
+		Files:       files,
+		Manifest:    pkgManifest,
+		Warnings:    selector.warnings,
+	}, nil
+}
 
-		// Write file
-		if err := os.WriteFile(targetPath, file.Content, 0644); err != nil {
-			return fileCount, fmt.Errorf("failed to write file %s: %w", file.Path, err)
-		}
+// InstallPackage installs a package from GitHub to the specified directory
+func (i *Installer) InstallPackage(owner, repo, installDir string) (int, error) {
+	pkg, err := i.FetchPackage(owner, repo)
+	if err != nil {
+		return 0, err
+	}
 
-		fileCount++
+	return WritePackage(pkg, installDir)
+}
+
+// WritePackage writes a fetched package into installDir/owner-repo/
+func WritePackage(pkg *Package, installDir string) (int, error) {
+	files, err := defaultTarget{}.Files(pkg)
+	if err != nil {
+		return 0, err
 	}
 
-	return fileCount, nil
+	return WriteFiles(installDir, files)
 }
 
 // UninstallPackage removes a package from the installation directory
@@ -101,7 +144,7 @@ func CountInstalledFiles(installDir, owner, repo string) (int, error) {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
+		if !info.IsDir() {
 			count++
 		}
 		return nil
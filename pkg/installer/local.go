@@ -0,0 +1,84 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
+)
+
+// LoadLocalPackage reads the installable files of a package from a local
+// directory, selecting them as FetchPackageAt does for a repository, and
+// names it owner/repo so it installs in place of the remote package
+func LoadLocalPackage(dir, owner, repo string) (*Package, error) {
+	var pkgManifest *manifest.PackageManifest
+	data, err := os.ReadFile(filepath.Join(dir, manifest.PackageFileName))
+	if err == nil {
+		if pkgManifest, err = manifest.ParsePackage(data); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", manifest.PackageFileName, err)
+	}
+
+	selector := &fileSelector{manifest: pkgManifest}
+	var files []github.FileContent
+	if err := walkLocalDir(dir, "", selector.include, &files); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no installable files found in %s", dir)
+	}
+
+	pkg := &Package{
+		Owner:    owner,
+		Repo:     repo,
+		Files:    files,
+		Manifest: pkgManifest,
+		Warnings: selector.warnings,
+	}
+	if pkgManifest != nil {
+		pkg.Description = pkgManifest.Description
+	}
+	return pkg, nil
+}
+
+// walkLocalDir collects the files include accepts in the same order as
+// walking the repository tree, skipping hidden files and directories
+func walkLocalDir(root, dirPath string, include func(string, int) bool, files *[]github.FileContent) error {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dirPath)))
+	if err != nil {
+		return fmt.Errorf("failed to read package directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		entryPath := path.Join(dirPath, entry.Name())
+
+		if entry.IsDir() {
+			if err := walkLocalDir(root, entryPath, include, files); err != nil {
+				return err
+			}
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if include(entryPath, int(info.Size())) {
+			content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(entryPath)))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", entryPath, err)
+			}
+			*files = append(*files, github.FileContent{Path: entryPath, Content: content})
+		}
+	}
+	return nil
+}
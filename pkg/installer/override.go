@@ -0,0 +1,101 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/github"
+)
+
+// OverridesDir holds project copies of package files that replace the
+// upstream content on every installation: .ai-overrides/owner-repo/<path>
+const OverridesDir = ".ai-overrides"
+
+// Override is a project file that replaces a package file
+type Override struct {
+	Source   string // path inside the package
+	Path     string // override file, slash-separated and relative to the project root
+	Content  []byte
+	Upstream []byte // upstream content, nil when the package has no such file
+}
+
+// ApplyOverrides replaces package files with the project's overrides.
+// Overrides are read from the overrides directory and from the overrides
+// map of the dependency config, which takes precedence. Overrides of files
+// the package does not contain are added to it.
+func ApplyOverrides(pkg *Package, projectDir string) ([]Override, error) {
+	sources := make(map[string]string)
+
+	// Files under .ai-overrides/owner-repo/
+	overrideDir := filepath.Join(projectDir, OverridesDir, pkg.Namespace())
+	err := filepath.WalkDir(overrideDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == overrideDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, filePath)
+		if err != nil {
+			return err
+		}
+		source, err := filepath.Rel(overrideDir, filePath)
+		if err != nil {
+			return err
+		}
+		sources[filepath.ToSlash(source)] = filepath.ToSlash(rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides: %w", err)
+	}
+
+	// Files mapped in the manifest, which must stay inside the package
+	for source, overridePath := range pkg.Options.Overrides {
+		cleaned := path.Clean(source)
+		if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("override source %s must be a path inside the package", source)
+		}
+		sources[cleaned] = filepath.ToSlash(overridePath)
+	}
+
+	var names []string
+	for source := range sources {
+		names = append(names, source)
+	}
+	sort.Strings(names)
+
+	var overrides []Override
+	for _, source := range names {
+		overridePath := sources[source]
+		content, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(overridePath)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read override %s: %w", overridePath, err)
+		}
+
+		override := Override{Source: source, Path: overridePath, Content: content}
+		found := false
+		for i, file := range pkg.Files {
+			if file.Path == source {
+				override.Upstream = file.Content
+				pkg.Files[i].Content = content
+				found = true
+				break
+			}
+		}
+		if !found {
+			pkg.Files = append(pkg.Files, github.FileContent{Path: source, Content: content})
+		}
+		overrides = append(overrides, override)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,78 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/patch"
+)
+
+// ApplyPatches applies the patches of the dependency config to the package
+// files, in order. Every hunk that no longer applies is reported.
+func ApplyPatches(pkg *Package, projectDir string) error {
+	for _, patchPath := range pkg.Options.Patches {
+		data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(patchPath)))
+		if err != nil {
+			return fmt.Errorf("failed to read patch %s: %w", patchPath, err)
+		}
+
+		diffs, err := patch.Parse(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse patch %s: %w", patchPath, err)
+		}
+
+		var conflicts []string
+		for _, diff := range diffs {
+			if err := applyDiff(pkg, diff); err != nil {
+				var conflict *patch.ConflictError
+				if !errors.As(err, &conflict) {
+					return fmt.Errorf("failed to apply patch %s: %w", patchPath, err)
+				}
+				conflicts = append(conflicts, conflict.Error())
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("patch %s does not apply to the current upstream files:\n%s", patchPath, strings.Join(conflicts, "\n"))
+		}
+	}
+	return nil
+}
+
+// applyDiff applies a single file diff to the package files
+func applyDiff(pkg *Package, diff *patch.FileDiff) error {
+	index := -1
+	if diff.OldPath != "" {
+		for i, file := range pkg.Files {
+			if file.Path == diff.OldPath {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return fmt.Errorf("%s is not part of the package", diff.OldPath)
+		}
+	}
+
+	var content []byte
+	if index != -1 {
+		content = pkg.Files[index].Content
+	}
+	patched, err := patch.Apply(content, diff)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case diff.NewPath == "":
+		pkg.Files = append(pkg.Files[:index], pkg.Files[index+1:]...)
+	case index == -1:
+		pkg.Files = append(pkg.Files, github.FileContent{Path: diff.NewPath, Content: patched})
+	default:
+		pkg.Files[index] = github.FileContent{Path: diff.NewPath, Content: patched}
+	}
+	return nil
+}
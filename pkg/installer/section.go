@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sectionBegin   = "<!-- skillmaster:begin %s -->"
+	sectionEnd     = "<!-- skillmaster:end %s -->"
+	composedHeader = "<!-- Sections between skillmaster markers are generated. Edit outside them. -->\n"
+)
+
+// WriteSection inserts or replaces the section with the given id in a shared
+// file. Content outside skillmaster sections is left untouched.
+func WriteSection(filePath, id string, content []byte) error {
+	existing, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	if len(existing) == 0 {
+		existing = []byte(composedHeader)
+	}
+
+	section := renderSection(id, content)
+
+	var updated []byte
+	if start, end, ok := findSection(existing, id); ok {
+		updated = append(append(append([]byte{}, existing[:start]...), section...), existing[end:]...)
+	} else {
+		updated = append([]byte{}, existing...)
+		if !bytes.HasSuffix(updated, []byte("\n")) {
+			updated = append(updated, '\n')
+		}
+		updated = append(append(updated, '\n'), section...)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filePath, err)
+	}
+	if err := os.WriteFile(filePath, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// RemoveSection deletes the section with the given id from a shared file.
+// The file is deleted when nothing but the generated header remains.
+func RemoveSection(filePath, id string) error {
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	start, end, ok := findSection(existing, id)
+	if !ok {
+		return nil
+	}
+	updated := append(append([]byte{}, existing[:start]...), existing[end:]...)
+	updated = bytes.ReplaceAll(updated, []byte("\n\n\n"), []byte("\n\n"))
+
+	remaining := strings.TrimSpace(strings.Replace(string(updated), strings.TrimSpace(composedHeader), "", 1))
+	if remaining == "" {
+		if err := os.Remove(filePath); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", filePath, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(filePath, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// ReadSection returns the content of the section with the given id
+func ReadSection(filePath, id string) ([]byte, bool, error) {
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	start, end, ok := findSection(existing, id)
+	if !ok {
+		return nil, false, nil
+	}
+	section := existing[start:end]
+	section = section[bytes.IndexByte(section, '\n')+1:]
+	section = section[:bytes.LastIndex(section, []byte(fmt.Sprintf(sectionEnd, id)))]
+	return section, true, nil
+}
+
+func renderSection(id string, content []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, sectionBegin+"\n", id)
+	buf.Write(sectionBody(content))
+	fmt.Fprintf(&buf, sectionEnd+"\n", id)
+	return buf.Bytes()
+}
+
+// sectionBody returns content as it is stored between the section markers
+func sectionBody(content []byte) []byte {
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		return append(append([]byte{}, content...), '\n')
+	}
+	return content
+}
+
+// findSection returns the byte range of a section including its markers
+// and trailing newline
+func findSection(content []byte, id string) (int, int, bool) {
+	begin := []byte(fmt.Sprintf(sectionBegin, id))
+	end := []byte(fmt.Sprintf(sectionEnd, id))
+
+	start := bytes.Index(content, begin)
+	if start == -1 {
+		return 0, 0, false
+	}
+	endIdx := bytes.Index(content[start:], end)
+	if endIdx == -1 {
+		return 0, 0, false
+	}
+	stop := start + endIdx + len(end)
+	if stop < len(content) && content[stop] == '\n' {
+		stop++
+	}
+	return start, stop, true
+}
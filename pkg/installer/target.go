@@ -0,0 +1,156 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/manifest"
+)
+
+// Target names
+const (
+	TargetDefault      = "default"
+	TargetClaudeSkills = "claude-skills"
+	TargetCursor       = "cursor"
+	TargetCopilot      = "copilot"
+)
+
+// OutputFile is a file produced by a target
+type OutputFile struct {
+	Path    string // slash-separated, relative to the install directory
+	Content []byte
+	Source  string // path inside the package the file was generated from
+	Section string // when set, Content is this package's section of a shared file
+}
+
+// TargetOptions configures a target
+type TargetOptions struct {
+	// Compose combines repository-wide instructions into a single shared
+	// file for targets that support it
+	Compose bool
+}
+
+// Target converts a fetched package into the layout an assistant expects
+type Target interface {
+	Name() string
+	Files(pkg *Package) ([]OutputFile, error)
+}
+
+// NewTarget returns the target with the given name.
+// An empty name selects the default owner-repo directory layout.
+func NewTarget(name string, opts TargetOptions) (Target, error) {
+	switch name {
+	case "", TargetDefault:
+		return defaultTarget{}, nil
+	case TargetClaudeSkills:
+		return claudeSkillsTarget{}, nil
+	case TargetCursor:
+		return cursorTarget{}, nil
+	case TargetCopilot:
+		return copilotTarget{compose: opts.Compose}, nil
+	}
+	return nil, fmt.Errorf("unknown install target: %s (available: %s)", name, strings.Join(TargetNames(), ", "))
+}
+
+// TargetNames returns the names of all available targets
+func TargetNames() []string {
+	names := []string{TargetDefault, TargetClaudeSkills, TargetCursor, TargetCopilot}
+	sort.Strings(names)
+	return names
+}
+
+// defaultTarget copies package files into the install directory using
+// the package's merge strategy:
+//   - separate:  installDir/owner-repo/path
+//   - namespace: installDir/dir/owner-repo-file
+//   - merge:     installDir/path
+type defaultTarget struct{}
+
+func (defaultTarget) Name() string {
+	return TargetDefault
+}
+
+func (defaultTarget) Files(pkg *Package) ([]OutputFile, error) {
+	strategy := pkg.Options.Strategy
+	if strategy == "" {
+		strategy = manifest.StrategySeparate
+	}
+	if !manifest.ValidStrategy(strategy) {
+		return nil, fmt.Errorf("unknown merge strategy: %s (expected %s, %s or %s)", strategy,
+			manifest.StrategySeparate, manifest.StrategyNamespace, manifest.StrategyMerge)
+	}
+
+	files := make([]OutputFile, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		var outputPath string
+		switch strategy {
+		case manifest.StrategySeparate:
+			outputPath = path.Join(pkg.Namespace(), file.Path)
+		case manifest.StrategyNamespace:
+			dir, name := path.Split(file.Path)
+			outputPath = path.Join(dir, pkg.Namespace()+"-"+name)
+		case manifest.StrategyMerge:
+			outputPath = file.Path
+		}
+		files = append(files, OutputFile{
+			Path:    outputPath,
+			Content: file.Content,
+			Source:  file.Path,
+		})
+	}
+	return files, nil
+}
+
+// WriteFiles writes target output files into installDir
+func WriteFiles(installDir string, files []OutputFile) (int, error) {
+	fileCount := 0
+	for _, file := range files {
+		targetPath := filepath.Join(installDir, filepath.FromSlash(file.Path))
+
+		// Shared files only receive this package's section
+		if file.Section != "" {
+			if err := WriteSection(targetPath, file.Section, file.Content); err != nil {
+				return fileCount, err
+			}
+			fileCount++
+			continue
+		}
+
+		// Create parent directories
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return fileCount, fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+
+		// Write file
+		if err := os.WriteFile(targetPath, file.Content, 0644); err != nil {
+			return fileCount, fmt.Errorf("failed to write file %s: %w", file.Path, err)
+		}
+
+		fileCount++
+	}
+
+	return fileCount, nil
+}
+
+// RemoveFiles deletes files relative to rootDir and prunes directories
+// left empty, keeping rootDir and its direct subdirectories (such as .ai)
+func RemoveFiles(rootDir string, paths []string) error {
+	for _, p := range paths {
+		fullPath := filepath.Join(rootDir, filepath.FromSlash(p))
+		if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+
+		// Prune empty parent directories
+		for dir := filepath.Dir(fullPath); dir != rootDir && filepath.Dir(dir) != rootDir && strings.HasPrefix(dir, rootDir); dir = filepath.Dir(dir) {
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
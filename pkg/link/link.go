@@ -0,0 +1,112 @@
+package link
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/config"
+)
+
+// RegistryFileName is the file registered package directories are kept in,
+// next to the global config
+const RegistryFileName = "links.json"
+
+// Link is a local package directory registered for linking
+type Link struct {
+	Name string `json:"name"`           // name from skillmaster-package.json
+	Repo string `json:"repo,omitempty"` // owner/repo the package is published as
+	Dir  string `json:"dir"`            // absolute path of the package directory
+}
+
+// Registry holds the registered package directories
+type Registry struct {
+	Links map[string]*Link `json:"links"` // keyed by package name
+
+	path string
+}
+
+// Load reads the registry. A missing registry is empty.
+func Load() (*Registry, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{
+		Links: make(map[string]*Link),
+		path:  filepath.Join(filepath.Dir(configPath), RegistryFileName),
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read link registry: %w", err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse link registry %s: %w", r.path, err)
+	}
+	if r.Links == nil {
+		r.Links = make(map[string]*Link)
+	}
+	return r, nil
+}
+
+// Save writes the registry
+func (r *Registry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal link registry: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write link registry: %w", err)
+	}
+	return nil
+}
+
+// Add registers a package directory, replacing an earlier registration of
+// the same package
+func (r *Registry) Add(l *Link) {
+	r.Links[l.Name] = l
+}
+
+// Remove unregisters a package. It reports whether it was registered.
+func (r *Registry) Remove(name string) bool {
+	if _, ok := r.Links[name]; !ok {
+		return false
+	}
+	delete(r.Links, name)
+	return true
+}
+
+// Find returns the link registered under a package name or owner/repo,
+// or nil
+func (r *Registry) Find(query string) *Link {
+	if l, ok := r.Links[query]; ok {
+		return l
+	}
+	for _, l := range r.Links {
+		if l.Repo != "" && strings.EqualFold(l.Repo, query) {
+			return l
+		}
+	}
+	return nil
+}
+
+// List returns the registered links sorted by name
+func (r *Registry) List() []*Link {
+	links := make([]*Link, 0, len(r.Links))
+	for _, l := range r.Links {
+		links = append(links, l)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Name < links[j].Name })
+	return links
+}
@@ -0,0 +1,273 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/manifest"
+)
+
+// Severities of findings. A rule set to SeverityOff does not run.
+const (
+	SeverityOff     = "off"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// File is a markdown file to lint
+type File struct {
+	Path    string // slash-separated path reported in findings
+	Package string // package that installed the file, if any
+	Content []byte
+}
+
+// Finding is a problem a rule found in a file
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Package  string `json:"package,omitempty"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the finding as file:line: severity: message (rule)
+func (f Finding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", location, f.Severity, f.Message, f.Rule)
+}
+
+// Rule checks instruction files. Rules that compare files with each other
+// receive every file at once.
+type Rule interface {
+	Name() string
+	Description() string
+	// DefaultSeverity applies when the configuration does not set one
+	DefaultSeverity() string
+	// Configure applies the options given in the configuration. It is not
+	// called when the rule is configured with a severity only.
+	Configure(options json.RawMessage) error
+	// Check returns the problems found; the linter sets their severity
+	Check(files []File) []Finding
+}
+
+// registry holds the constructors of the available rules
+var registry = map[string]func() Rule{}
+
+// Register makes a rule available under its name. newRule must return the
+// rule with its default options. It panics when the name is already taken.
+func Register(newRule func() Rule) {
+	name := newRule().Name()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("lint rule %s registered twice", name))
+	}
+	registry[name] = newRule
+}
+
+func init() {
+	Register(func() Rule { return newMaxSize() })
+	Register(func() Rule { return &requiredHeadings{} })
+	Register(func() Rule { return &noAbsolutePaths{} })
+	Register(func() Rule { return newNoTodo() })
+	Register(func() Rule { return newDuplicateHeadings() })
+}
+
+// Rules returns the available rules with their default options, by name
+func Rules() []Rule {
+	names := ruleNames()
+	rules := make([]Rule, 0, len(names))
+	for _, name := range names {
+		rules = append(rules, registry[name]())
+	}
+	return rules
+}
+
+// Linter runs configured rules
+type Linter struct {
+	rules    []Rule
+	severity map[string]string
+}
+
+// New returns a linter running every rule with the given configuration.
+// A nil configuration runs the rules with their defaults.
+func New(config *manifest.LintConfig) (*Linter, error) {
+	l := &Linter{severity: make(map[string]string)}
+	for _, rule := range Rules() {
+		l.rules = append(l.rules, rule)
+		l.severity[rule.Name()] = rule.DefaultSeverity()
+	}
+	if config == nil {
+		return l, nil
+	}
+
+	names := make([]string, 0, len(config.Rules))
+	for name := range config.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		raw := config.Rules[name]
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown lint rule: %s (available: %s)", name, strings.Join(ruleNames(), ", "))
+		}
+		severity, options, err := parseRuleConfig(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration of lint rule %s: %w", name, err)
+		}
+		if severity != "" {
+			l.severity[name] = severity
+		}
+		if options != nil {
+			if err := l.rule(name).Configure(options); err != nil {
+				return nil, fmt.Errorf("invalid configuration of lint rule %s: %w", name, err)
+			}
+		}
+	}
+	return l, nil
+}
+
+// Run lints the files and returns the findings sorted by file and line
+func (l *Linter) Run(files []File) []Finding {
+	packages := make(map[string]string)
+	for _, file := range files {
+		packages[file.Path] = file.Package
+	}
+
+	findings := []Finding{}
+	for _, rule := range l.rules {
+		severity := l.severity[rule.Name()]
+		if severity == SeverityOff {
+			continue
+		}
+		for _, finding := range rule.Check(files) {
+			finding.Rule = rule.Name()
+			finding.Severity = severity
+			finding.Package = packages[finding.File]
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return findings
+}
+
+// Rules returns the configured rules, by name
+func (l *Linter) Rules() []Rule {
+	return append([]Rule{}, l.rules...)
+}
+
+// Severity returns the severity a rule runs with
+func (l *Linter) Severity(name string) string {
+	return l.severity[name]
+}
+
+func (l *Linter) rule(name string) Rule {
+	for _, rule := range l.rules {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+func ruleNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseRuleConfig splits a rule configuration into its severity and the
+// remaining options, which are nil when only a severity is given
+func parseRuleConfig(raw json.RawMessage) (string, json.RawMessage, error) {
+	var severity string
+	if err := json.Unmarshal(raw, &severity); err == nil {
+		if !validSeverity(severity) {
+			return "", nil, fmt.Errorf("invalid severity %q (use off, warning or error)", severity)
+		}
+		return severity, nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", nil, fmt.Errorf("expected a severity or an object")
+	}
+	if value, ok := fields["severity"]; ok {
+		if err := json.Unmarshal(value, &severity); err != nil || !validSeverity(severity) {
+			return "", nil, fmt.Errorf("invalid severity %s (use off, warning or error)", value)
+		}
+		delete(fields, "severity")
+	}
+	if len(fields) == 0 {
+		return severity, nil, nil
+	}
+	options, err := json.Marshal(fields)
+	if err != nil {
+		return "", nil, err
+	}
+	return severity, options, nil
+}
+
+func validSeverity(s string) bool {
+	return s == SeverityOff || s == SeverityWarning || s == SeverityError
+}
+
+// decodeOptions decodes rule options, rejecting unknown ones
+func decodeOptions(options json.RawMessage, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(options))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("failed to parse options: %w", err)
+	}
+	return nil
+}
+
+// body returns the markdown after the frontmatter and the number of lines
+// that precede it
+func body(content []byte) ([]byte, int) {
+	_, rest, err := frontmatter.Parse(content)
+	if err != nil {
+		return content, 0
+	}
+	return rest, bytes.Count(content[:len(content)-len(rest)], []byte("\n"))
+}
+
+// line is a markdown line outside fenced code blocks
+type line struct {
+	Number int // 1-based line number in the file
+	Text   string
+}
+
+// proseLines returns the lines of a file outside its frontmatter and fenced
+// code blocks
+func proseLines(content []byte) []line {
+	text, offset := body(content)
+	var lines []line
+	inFence := false
+	for i, s := range strings.Split(string(text), "\n") {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			lines = append(lines, line{Number: offset + i + 1, Text: strings.TrimRight(s, "\r")})
+		}
+	}
+	return lines
+}
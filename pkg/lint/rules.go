@@ -0,0 +1,316 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"skillmaster/pkg/glob"
+)
+
+// maxSize limits the size of a file, since every instruction file loaded
+// by an assistant uses up its context window
+type maxSize struct {
+	MaxBytes  int `json:"maxBytes"`
+	MaxTokens int `json:"maxTokens"`
+}
+
+func newMaxSize() *maxSize {
+	return &maxSize{MaxBytes: 64 * 1024, MaxTokens: 8000}
+}
+
+func (r *maxSize) Name() string { return "max-size" }
+
+func (r *maxSize) Description() string {
+	return fmt.Sprintf("Files must stay below maxBytes (%d) and an estimated maxTokens (%d)", r.MaxBytes, r.MaxTokens)
+}
+
+func (r *maxSize) DefaultSeverity() string { return SeverityWarning }
+
+func (r *maxSize) Configure(options json.RawMessage) error {
+	if err := decodeOptions(options, r); err != nil {
+		return err
+	}
+	if r.MaxBytes < 0 || r.MaxTokens < 0 {
+		return fmt.Errorf("maxBytes and maxTokens must not be negative")
+	}
+	return nil
+}
+
+func (r *maxSize) Check(files []File) []Finding {
+	var findings []Finding
+	for _, file := range files {
+		if r.MaxBytes > 0 && len(file.Content) > r.MaxBytes {
+			findings = append(findings, Finding{File: file.Path, Message: fmt.Sprintf("file is %d bytes, more than the limit of %d", len(file.Content), r.MaxBytes)})
+			continue
+		}
+		if tokens := EstimateTokens(file.Content); r.MaxTokens > 0 && tokens > r.MaxTokens {
+			findings = append(findings, Finding{File: file.Path, Message: fmt.Sprintf("file is about %d tokens, more than the limit of %d", tokens, r.MaxTokens)})
+		}
+	}
+	return findings
+}
+
+// EstimateTokens approximates the number of tokens of a text at four
+// characters per token
+func EstimateTokens(content []byte) int {
+	return (utf8.RuneCount(content) + 3) / 4
+}
+
+// requiredHeadings requires headings in the files matching a glob
+type requiredHeadings struct {
+	Headings []string `json:"headings"`
+	Files    []string `json:"files"` // globs of the files to check; empty for all
+}
+
+func (r *requiredHeadings) Name() string { return "required-headings" }
+
+func (r *requiredHeadings) Description() string {
+	return "Files must contain the configured headings"
+}
+
+func (r *requiredHeadings) DefaultSeverity() string { return SeverityError }
+
+func (r *requiredHeadings) Configure(options json.RawMessage) error {
+	if err := decodeOptions(options, r); err != nil {
+		return err
+	}
+	for _, pattern := range r.Files {
+		if !glob.Valid(pattern) {
+			return fmt.Errorf("invalid glob: %s", pattern)
+		}
+	}
+	return nil
+}
+
+func (r *requiredHeadings) Check(files []File) []Finding {
+	var findings []Finding
+	for _, file := range files {
+		if len(r.Headings) == 0 || (len(r.Files) > 0 && !glob.MatchAny(r.Files, file.Path)) {
+			continue
+		}
+		present := make(map[string]bool)
+		for _, l := range proseLines(file.Content) {
+			if _, text, ok := heading(l.Text); ok {
+				present[normalizeHeading(text)] = true
+			}
+		}
+		for _, required := range r.Headings {
+			if !present[normalizeHeading(required)] {
+				findings = append(findings, Finding{File: file.Path, Message: fmt.Sprintf("missing required heading %q", required)})
+			}
+		}
+	}
+	return findings
+}
+
+// noAbsolutePaths reports paths that only exist on the author's machine,
+// such as home directories and Windows drive paths
+type noAbsolutePaths struct {
+	Allow []string `json:"allow"` // path prefixes that are allowed
+}
+
+var absolutePath = regexp.MustCompile(`(?:^|[^\w.~/\\-])((?:/Users|/home|/root|/private/var|/var/folders)/[^\s)\]"'` + "`" + `<>]*|[A-Za-z]:\\[^\s)\]"'` + "`" + `<>]*|[A-Za-z]:/[^/\s)\]"'` + "`" + `<>][^\s)\]"'` + "`" + `<>]*)`)
+
+func (r *noAbsolutePaths) Name() string { return "no-absolute-paths" }
+
+func (r *noAbsolutePaths) Description() string {
+	return "Files must not contain local absolute paths such as /Users/me or C:\\Users\\me"
+}
+
+func (r *noAbsolutePaths) DefaultSeverity() string { return SeverityError }
+
+func (r *noAbsolutePaths) Configure(options json.RawMessage) error {
+	return decodeOptions(options, r)
+}
+
+func (r *noAbsolutePaths) Check(files []File) []Finding {
+	var findings []Finding
+	for _, file := range files {
+		text, offset := body(file.Content)
+		for i, s := range strings.Split(string(text), "\n") {
+			for _, match := range absolutePath.FindAllStringSubmatch(s, -1) {
+				p := strings.TrimRight(match[1], ".,;:!?")
+				if r.allowed(p) {
+					continue
+				}
+				findings = append(findings, Finding{File: file.Path, Line: offset + i + 1, Message: fmt.Sprintf("local absolute path %s", p)})
+			}
+		}
+	}
+	return findings
+}
+
+func (r *noAbsolutePaths) allowed(p string) bool {
+	for _, prefix := range r.Allow {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// noTodo reports unfinished content marked with TODO and similar markers.
+// Code blocks are skipped, since examples often contain such comments.
+type noTodo struct {
+	Markers []string `json:"markers"`
+	pattern *regexp.Regexp
+}
+
+func newNoTodo() *noTodo {
+	r := &noTodo{Markers: []string{"TODO", "FIXME", "XXX", "TBD"}}
+	r.compile()
+	return r
+}
+
+func (r *noTodo) compile() {
+	quoted := make([]string, len(r.Markers))
+	for i, marker := range r.Markers {
+		quoted[i] = regexp.QuoteMeta(marker)
+	}
+	r.pattern = regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+}
+
+func (r *noTodo) Name() string { return "no-todo" }
+
+func (r *noTodo) Description() string {
+	return fmt.Sprintf("Files must not contain %s markers outside code", strings.Join(r.Markers, ", "))
+}
+
+func (r *noTodo) DefaultSeverity() string { return SeverityWarning }
+
+func (r *noTodo) Configure(options json.RawMessage) error {
+	if err := decodeOptions(options, r); err != nil {
+		return err
+	}
+	if len(r.Markers) == 0 {
+		return fmt.Errorf("markers must not be empty; set the rule to off instead")
+	}
+	r.compile()
+	return nil
+}
+
+func (r *noTodo) Check(files []File) []Finding {
+	var findings []Finding
+	for _, file := range files {
+		for _, l := range proseLines(file.Content) {
+			text := inlineCode.ReplaceAllString(l.Text, "")
+			if match := r.pattern.FindString(text); match != "" {
+				findings = append(findings, Finding{File: file.Path, Line: l.Number, Message: fmt.Sprintf("%s marker left in the text", match)})
+			}
+		}
+	}
+	return findings
+}
+
+var inlineCode = regexp.MustCompile("`[^`]*`")
+
+// duplicateHeadings reports a heading used in several files with different
+// content, since assistants may receive contradicting instructions
+type duplicateHeadings struct {
+	Ignore []string `json:"ignore"` // generic headings that may differ
+}
+
+func newDuplicateHeadings() *duplicateHeadings {
+	return &duplicateHeadings{Ignore: []string{"Overview", "Introduction", "Examples", "Example", "Usage", "Notes", "Summary", "References", "See Also"}}
+}
+
+func (r *duplicateHeadings) Name() string { return "duplicate-headings" }
+
+func (r *duplicateHeadings) Description() string {
+	return "A heading used in several files must have the same content in each"
+}
+
+func (r *duplicateHeadings) DefaultSeverity() string { return SeverityWarning }
+
+func (r *duplicateHeadings) Configure(options json.RawMessage) error {
+	return decodeOptions(options, r)
+}
+
+// section is the content below a heading, up to the next heading of the
+// same or a higher level
+type section struct {
+	File    string
+	Line    int
+	Heading string
+	Content string
+}
+
+func (r *duplicateHeadings) Check(files []File) []Finding {
+	ignored := make(map[string]bool)
+	for _, h := range r.Ignore {
+		ignored[normalizeHeading(h)] = true
+	}
+
+	// First occurrence of each heading, in file order
+	first := make(map[string]section)
+	var findings []Finding
+	for _, file := range files {
+		for _, s := range sections(file) {
+			key := normalizeHeading(s.Heading)
+			if ignored[key] {
+				continue
+			}
+			earlier, ok := first[key]
+			if !ok {
+				first[key] = s
+				continue
+			}
+			if earlier.File != s.File && earlier.Content != s.Content {
+				findings = append(findings, Finding{File: s.File, Line: s.Line, Message: fmt.Sprintf("heading %q is also in %s:%d with different content", s.Heading, earlier.File, earlier.Line)})
+			}
+		}
+	}
+	return findings
+}
+
+// sections splits a file into the sections of its headings
+func sections(file File) []section {
+	type open struct {
+		level int
+		index int
+	}
+	var result []section
+	var stack []open
+	var contents [][]string
+	for _, l := range proseLines(file.Content) {
+		if level, text, ok := heading(l.Text); ok {
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			for _, o := range stack {
+				contents[o.index] = append(contents[o.index], l.Text)
+			}
+			result = append(result, section{File: file.Path, Line: l.Number, Heading: text})
+			contents = append(contents, nil)
+			stack = append(stack, open{level: level, index: len(result) - 1})
+			continue
+		}
+		if text := strings.Join(strings.Fields(l.Text), " "); text != "" {
+			for _, o := range stack {
+				contents[o.index] = append(contents[o.index], text)
+			}
+		}
+	}
+	for i := range result {
+		result[i].Content = strings.Join(contents[i], "\n")
+	}
+	return result
+}
+
+var atxHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+
+// heading parses an ATX heading line
+func heading(s string) (int, string, bool) {
+	match := atxHeading.FindStringSubmatch(s)
+	if match == nil || match[2] == "" {
+		return 0, "", false
+	}
+	return len(match[1]), match[2], true
+}
+
+func normalizeHeading(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
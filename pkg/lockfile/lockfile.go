@@ -0,0 +1,213 @@
+package lockfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockedFile is a file written into the project by an installation
+type LockedFile struct {
+	Path    string `json:"path"`              // slash-separated, relative to the project root
+	Profile string `json:"profile,omitempty"` // empty for the default install directory
+	Target  string `json:"target"`
+	Source  string `json:"source,omitempty"`  // path inside the package the file was generated from
+	Section string `json:"section,omitempty"` // set when the package owns only a section of a shared file
+	Hash    string `json:"hash,omitempty"`    // content hash at install time, of the section for shared files
+}
+
+// LockedOverride records the upstream file a project override replaced
+type LockedOverride struct {
+	Source       string `json:"source"` // path inside the package
+	Path         string `json:"path"`   // override file, relative to the project root
+	UpstreamHash string `json:"upstreamHash,omitempty"`
+	OverrideHash string `json:"overrideHash"`
+}
+
+// Metadata holds the skillmaster-package.json fields of an installed package
+type Metadata struct {
+	Name        string   `json:"name,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+// LockedPackage records what was installed for a package
+type LockedPackage struct {
+	Version   string           `json:"version"`
+	Ref       string           `json:"ref,omitempty"`
+	Link      string           `json:"link,omitempty"` // local directory a linked package is installed from; Version stays pinned
+	Metadata  *Metadata        `json:"metadata,omitempty"`
+	Files     []LockedFile     `json:"files"`
+	Overrides []LockedOverride `json:"overrides,omitempty"`
+	// Dependencies maps the packages this package depends on to the version
+	// range it requires; nil when the dependencies were never resolved
+	Dependencies map[string]string `json:"dependencies"`
+	// Transitive is set when the package is installed only because other
+	// packages depend on it
+	Transitive bool `json:"transitive,omitempty"`
+}
+
+// LockFile represents the skillmaster.lock file
+type LockFile struct {
+	LockfileVersion int                       `json:"lockfileVersion"`
+	Packages        map[string]*LockedPackage `json:"packages"`
+}
+
+const (
+	LockFileName   = "skillmaster.lock"
+	currentVersion = 1
+)
+
+// New creates an empty lock file
+func New() *LockFile {
+	return &LockFile{
+		LockfileVersion: currentVersion,
+		Packages:        make(map[string]*LockedPackage),
+	}
+}
+
+// Load reads the lock file from the given directory.
+// A missing lock file is not an error and yields an empty lock file.
+func Load(dir string) (*LockFile, error) {
+	lockPath := filepath.Join(dir, LockFileName)
+
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(), nil
+		}
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lock LockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+
+	if lock.Packages == nil {
+		lock.Packages = make(map[string]*LockedPackage)
+	}
+
+	return &lock, nil
+}
+
+// Save writes the lock file to the given directory
+func (l *LockFile) Save(dir string) error {
+	lockPath := filepath.Join(dir, LockFileName)
+
+	// Keep file order stable so the lock file diffs cleanly
+	for _, pkg := range l.Packages {
+		sort.Slice(pkg.Files, func(i, j int) bool {
+			if pkg.Files[i].Profile != pkg.Files[j].Profile {
+				return pkg.Files[i].Profile < pkg.Files[j].Profile
+			}
+			return pkg.Files[i].Path < pkg.Files[j].Path
+		})
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock file: %w", err)
+	}
+
+	if err := os.WriteFile(lockPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	return nil
+}
+
+// Package returns the locked entry for a package, or nil if it is not locked
+func (l *LockFile) Package(name string) *LockedPackage {
+	return l.Packages[name]
+}
+
+// SetPackage records the version of a package, keeping any locked files
+func (l *LockFile) SetPackage(name, version, ref string) *LockedPackage {
+	pkg, ok := l.Packages[name]
+	if !ok {
+		pkg = &LockedPackage{}
+		l.Packages[name] = pkg
+	}
+	pkg.Version = version
+	pkg.Ref = ref
+	return pkg
+}
+
+// RemovePackage removes a package from the lock file
+func (l *LockFile) RemovePackage(name string) {
+	delete(l.Packages, name)
+}
+
+// Owner returns the packages that installed a file at the given path.
+// Shared files can have several owners, each owning one section.
+func (l *LockFile) Owner(filePath string) []string {
+	var owners []string
+	for name, pkg := range l.Packages {
+		for _, file := range pkg.Files {
+			if file.Path == filePath {
+				owners = append(owners, name)
+				break
+			}
+		}
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// Dependents returns the locked packages that depend on the given package
+func (l *LockFile) Dependents(name string) []string {
+	var dependents []string
+	for other, pkg := range l.Packages {
+		if _, ok := pkg.Dependencies[name]; ok {
+			dependents = append(dependents, other)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// Override returns the recorded override of a package file, or nil
+func (p *LockedPackage) Override(source string) *LockedOverride {
+	for i := range p.Overrides {
+		if p.Overrides[i].Source == source {
+			return &p.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// FilesFor returns the files installed for the given profile
+func (p *LockedPackage) FilesFor(profile string) []LockedFile {
+	var files []LockedFile
+	for _, file := range p.Files {
+		if file.Profile == profile {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// SetFiles replaces the files installed for the given profile
+func (p *LockedPackage) SetFiles(profile string, files []LockedFile) {
+	kept := p.Files[:0]
+	for _, file := range p.Files {
+		if file.Profile != profile {
+			kept = append(kept, file)
+		}
+	}
+	p.Files = append(kept, files...)
+}
+
+// Hash returns the content hash recorded in the lock file
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256-" + hex.EncodeToString(sum[:])
+}
@@ -1,24 +1,117 @@
 package manifest
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+)
+
+// Profile describes a project-specific assistant installation location
+type Profile struct {
+	InstallDir string `json:"installDir"`
+	Target     string `json:"target,omitempty"`
+	Compose    bool   `json:"compose,omitempty"`
+}
+
+// Merge strategies for the default install target
+const (
+	StrategySeparate  = "separate"  // installDir/owner-repo/path
+	StrategyNamespace = "namespace" // installDir/dir/owner-repo-file
+	StrategyMerge     = "merge"     // installDir/path
 )
 
 // Config represents the configuration section in the manifest
 type Config struct {
 	InstallDir string `json:"installDir"`
-	AutoMerge  bool   `json:"autoMerge"`
+	Strategy   string `json:"strategy,omitempty"`
+	// Deprecated: AutoMerge was never applied; use Strategy instead.
+	AutoMerge bool               `json:"autoMerge,omitempty"`
+	Profiles  map[string]Profile `json:"profiles,omitempty"`
+	// Conflicts is the policy applied when packages write the same file
+	Conflicts string `json:"conflicts,omitempty"`
+}
+
+// CursorRule holds Cursor rule settings for installed files
+type CursorRule struct {
+	Description string   `json:"description,omitempty"`
+	Globs       []string `json:"globs,omitempty"`
+	AlwaysApply *bool    `json:"alwaysApply,omitempty"`
+}
+
+// CursorOptions overrides how a package is converted into Cursor rules.
+// Settings in Files apply to a single package file and take precedence.
+type CursorOptions struct {
+	CursorRule
+	Files map[string]CursorRule `json:"files,omitempty"`
+}
+
+// CopilotOptions overrides the applyTo glob of Copilot instruction files.
+// Files maps a package file to its own applyTo and takes precedence.
+type CopilotOptions struct {
+	ApplyTo string            `json:"applyTo,omitempty"`
+	Files   map[string]string `json:"files,omitempty"`
+}
+
+// DependencyConfig holds per-dependency installation options
+type DependencyConfig struct {
+	Strategy string          `json:"strategy,omitempty"`
+	Cursor   *CursorOptions  `json:"cursor,omitempty"`
+	Copilot  *CopilotOptions `json:"copilot,omitempty"`
+	// Overrides maps a package file to a project file that replaces it
+	Overrides map[string]string `json:"overrides,omitempty"`
+	// Patches lists unified diffs applied to the package files after overrides
+	Patches []string `json:"patches,omitempty"`
+}
+
+// IsEmpty reports whether the config sets no options
+func (d DependencyConfig) IsEmpty() bool {
+	return d.Strategy == "" && d.Cursor == nil && d.Copilot == nil && len(d.Overrides) == 0 && len(d.Patches) == 0
+}
+
+// Compose modes
+const (
+	ComposeImport = "import"
+	ComposeInline = "inline"
+)
+
+// ComposeFile describes a root instructions file such as AGENTS.md or
+// CLAUDE.md that is built from installed package files
+type ComposeFile struct {
+	Path     string   `json:"path"`
+	Mode     string   `json:"mode,omitempty"`     // import (@-references) or inline
+	Packages []string `json:"packages,omitempty"` // packages to include, in order; empty for all
+	Include  []string `json:"include,omitempty"`  // package file globs, in order; empty for all instruction files
+	Template string   `json:"template,omitempty"` // file the output starts from when it does not exist yet
+}
+
+// ComposeConfig configures the compose command
+type ComposeConfig struct {
+	Files     []ComposeFile `json:"files,omitempty"`
+	OnInstall bool          `json:"onInstall,omitempty"`
+}
+
+// LintConfig configures the lint command. Rules maps a rule name to a
+// severity ("off", "warning" or "error") or to an object with a severity
+// and the rule's options.
+type LintConfig struct {
+	Rules map[string]json.RawMessage `json:"rules,omitempty"`
 }
 
 // Manifest represents the skillmaster.json file
 type Manifest struct {
-	Name         string            `json:"name"`
-	Version      string            `json:"version"`
-	Dependencies map[string]string `json:"dependencies"`
-	Config       Config            `json:"config"`
+	Name             string                      `json:"name"`
+	Version          string                      `json:"version"`
+	Dependencies     map[string]string           `json:"dependencies"`
+	DependencyConfig map[string]DependencyConfig `json:"dependencyConfig,omitempty"`
+	Compose          *ComposeConfig              `json:"compose,omitempty"`
+	Lint             *LintConfig                 `json:"lint,omitempty"`
+	Config           Config                      `json:"config"`
+
+	// order keeps dependencies in the order they appear in skillmaster.json
+	order []string
 }
 
 const ManifestFileName = "skillmaster.json"
@@ -31,7 +124,7 @@ func New(name string) *Manifest {
 		Dependencies: make(map[string]string),
 		Config: Config{
 			InstallDir: ".ai",
-			AutoMerge:  true,
+			Strategy:   StrategySeparate,
 		},
 	}
 }
@@ -39,7 +132,7 @@ func New(name string) *Manifest {
 // Load reads and parses the manifest file from the given directory
 func Load(dir string) (*Manifest, error) {
 	manifestPath := filepath.Join(dir, ManifestFileName)
-	
+
 	data, err := os.ReadFile(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -58,10 +151,21 @@ func Load(dir string) (*Manifest, error) {
 		manifest.Dependencies = make(map[string]string)
 	}
 
+	// Remember the order dependencies are listed in
+	order, err := dependencyOrder(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	manifest.order = order
+
 	// Set default config values if not specified
 	if manifest.Config.InstallDir == "" {
 		manifest.Config.InstallDir = ".ai"
 	}
+	if manifest.Config.Strategy == "" {
+		manifest.Config.Strategy = StrategySeparate
+	}
+	manifest.Config.AutoMerge = false
 
 	return &manifest, nil
 }
@@ -69,7 +173,7 @@ func Load(dir string) (*Manifest, error) {
 // Save writes the manifest to the given directory
 func (m *Manifest) Save(dir string) error {
 	manifestPath := filepath.Join(dir, ManifestFileName)
-	
+
 	data, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal manifest: %w", err)
@@ -82,19 +186,156 @@ func (m *Manifest) Save(dir string) error {
 	return nil
 }
 
+// MarshalJSON writes the manifest keeping dependencies in manifest order
+func (m *Manifest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name             string                      `json:"name"`
+		Version          string                      `json:"version"`
+		Dependencies     orderedDependencies         `json:"dependencies"`
+		DependencyConfig map[string]DependencyConfig `json:"dependencyConfig,omitempty"`
+		Compose          *ComposeConfig              `json:"compose,omitempty"`
+		Lint             *LintConfig                 `json:"lint,omitempty"`
+		Config           Config                      `json:"config"`
+	}{
+		Name:             m.Name,
+		Version:          m.Version,
+		Dependencies:     orderedDependencies{names: m.DependencyNames(), versions: m.Dependencies},
+		DependencyConfig: m.DependencyConfig,
+		Compose:          m.Compose,
+		Lint:             m.Lint,
+		Config:           m.Config,
+	})
+}
+
+// DependencyNames returns the dependency names in manifest order.
+// Dependencies added since the manifest was loaded come last.
+func (m *Manifest) DependencyNames() []string {
+	names := make([]string, 0, len(m.Dependencies))
+	seen := make(map[string]bool)
+	for _, name := range m.order {
+		if _, ok := m.Dependencies[name]; ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	var added []string
+	for name := range m.Dependencies {
+		if !seen[name] {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+
+	return append(names, added...)
+}
+
 // AddDependency adds or updates a dependency in the manifest
 func (m *Manifest) AddDependency(name, version string) {
 	if m.Dependencies == nil {
 		m.Dependencies = make(map[string]string)
 	}
+	if _, ok := m.Dependencies[name]; !ok {
+		m.order = append(m.DependencyNames(), name)
+	}
 	m.Dependencies[name] = version
 }
 
-// RemoveDependency removes a dependency from the manifest
+// RemoveDependency removes a dependency and its options from the manifest
 func (m *Manifest) RemoveDependency(name string) {
 	if m.Dependencies != nil {
 		delete(m.Dependencies, name)
 	}
+	if m.DependencyConfig != nil {
+		delete(m.DependencyConfig, name)
+	}
+}
+
+// GetDependencyConfig returns the installation options for a dependency
+// with project-wide defaults applied
+func (m *Manifest) GetDependencyConfig(name string) DependencyConfig {
+	config := m.DependencyConfig[name]
+	if config.Strategy == "" {
+		config.Strategy = m.Config.Strategy
+	}
+	return config
+}
+
+// ValidStrategy reports whether s is a known merge strategy
+func ValidStrategy(s string) bool {
+	switch s {
+	case StrategySeparate, StrategyNamespace, StrategyMerge:
+		return true
+	}
+	return false
+}
+
+// orderedDependencies marshals dependencies as a JSON object in a fixed order
+type orderedDependencies struct {
+	names    []string
+	versions map[string]string
+}
+
+func (d orderedDependencies) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range d.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(d.versions[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// dependencyOrder returns the keys of the "dependencies" object in file order
+func dependencyOrder(data []byte) ([]string, error) {
+	var raw struct {
+		Dependencies json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw.Dependencies) == 0 || string(raw.Dependencies) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw.Dependencies))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid dependency name: %v", token)
+		}
+		names = append(names, name)
+
+		// Skip the value
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return names, nil
 }
 
 // Exists checks if a manifest file exists in the given directory
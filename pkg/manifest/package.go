@@ -0,0 +1,163 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"skillmaster/pkg/glob"
+)
+
+// PackageFileName is the metadata file published packages keep at their root
+const PackageFileName = "skillmaster-package.json"
+
+// PackageManifest represents the skillmaster-package.json file of a package
+type PackageManifest struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Files       []string `json:"files,omitempty"`   // globs of the files to install; empty for all
+	Exclude     []string `json:"exclude,omitempty"` // globs of files never installed
+	Assets      []string `json:"assets,omitempty"`  // globs of non-markdown files to install
+	// Dependencies maps other packages (owner/repo) to the version range required
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+	// Lint configures the lint rules run on the package
+	Lint *LintConfig `json:"lint,omitempty"`
+	// Cursor sets the default Cursor rule settings of the package's files;
+	// consumers can still override them in dependencyConfig
+	Cursor *CursorOptions `json:"cursor,omitempty"`
+	// Copilot sets the default applyTo globs of the package's files;
+	// consumers can still override them in dependencyConfig
+	Copilot *CopilotOptions `json:"copilot,omitempty"`
+}
+
+// ParsePackage parses the content of a skillmaster-package.json file
+func ParsePackage(data []byte) (*PackageManifest, error) {
+	var pkg PackageManifest
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackageFileName, err)
+	}
+
+	for _, pattern := range append(append(append([]string{}, pkg.Files...), pkg.Exclude...), pkg.Assets...) {
+		if !glob.Valid(pattern) {
+			return nil, fmt.Errorf("invalid glob in %s: %s", PackageFileName, pattern)
+		}
+	}
+
+	for name := range pkg.Dependencies {
+		if parts := strings.Split(name, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid dependency in %s: %s (expected owner/repo)", PackageFileName, name)
+		}
+	}
+
+	return &pkg, nil
+}
+
+// LoadPackage reads the skillmaster-package.json file from the given directory
+func LoadPackage(dir string) (*PackageManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PackageFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s not found", PackageFileName)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", PackageFileName, err)
+	}
+	return ParsePackage(data)
+}
+
+// Includes reports whether a package file is installed: it must match one
+// of the files globs, when any are given, and none of the exclude globs.
+// A glob matching a directory applies to everything below it.
+func (p *PackageManifest) Includes(filePath string) bool {
+	if len(p.Files) > 0 && !matchesPathOrParent(p.Files, filePath) {
+		return false
+	}
+	return !matchesPathOrParent(p.Exclude, filePath)
+}
+
+// IncludesAsset reports whether a non-markdown file is installed: it must
+// match one of the assets globs and none of the exclude globs. The metadata
+// file itself is never installed.
+func (p *PackageManifest) IncludesAsset(filePath string) bool {
+	if filePath == PackageFileName {
+		return false
+	}
+	return matchesPathOrParent(p.Assets, filePath) && !matchesPathOrParent(p.Exclude, filePath)
+}
+
+// MatchGlob reports whether a files, exclude or assets glob matches a
+// package file or one of its parent directories
+func MatchGlob(pattern, filePath string) bool {
+	return matchesPathOrParent([]string{pattern}, filePath)
+}
+
+// ValidPackageName reports whether a package name can be used as a GitHub
+// repository name
+func ValidPackageName(name string) bool {
+	return packageNamePattern.MatchString(name) && name != "." && name != ".."
+}
+
+var packageNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// matchesPathOrParent reports whether a pattern matches the path or one
+// of its parent directories
+func matchesPathOrParent(patterns []string, filePath string) bool {
+	for i := range patterns {
+		pattern := strings.TrimSuffix(strings.TrimPrefix(patterns[i], "/"), "/")
+		for name := filePath; name != "." && name != ""; name = path.Dir(name) {
+			if glob.Match(pattern, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetPackageVersion replaces the top-level version of skillmaster-package.json
+// content, leaving the rest of the file as it was written
+func SetPackageVersion(data []byte, version string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("failed to parse %s: expected an object", PackageFileName)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", PackageFileName, err)
+		}
+		keyEnd := decoder.InputOffset()
+
+		if token != "version" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", PackageFileName, err)
+			}
+			continue
+		}
+
+		var current string
+		if err := decoder.Decode(&current); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: version must be a string", PackageFileName)
+		}
+		valueEnd := int(decoder.InputOffset())
+		valueStart := int(keyEnd) + bytes.IndexByte(data[keyEnd:valueEnd], '"')
+
+		quoted, err := json.Marshal(version)
+		if err != nil {
+			return nil, err
+		}
+		updated := append([]byte{}, data[:valueStart]...)
+		updated = append(updated, quoted...)
+		return append(updated, data[valueEnd:]...), nil
+	}
+	return nil, fmt.Errorf("%s has no version", PackageFileName)
+}
@@ -0,0 +1,159 @@
+package pack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/validate"
+)
+
+// Prefix is the directory all files of an archive are stored in
+const Prefix = "package"
+
+// IndexFileName is the manifest embedded in every archive, listing the
+// packed files with their sizes and hashes
+const IndexFileName = "skillmaster-pack.json"
+
+// modTime is the modification time of every entry, so that packing the same
+// files always produces the same archive
+var modTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Index is the embedded manifest of an archive
+type Index struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Files   []IndexFile `json:"files"` // files a consumer installs, sorted by path
+}
+
+// IndexFile is a packed file
+type IndexFile struct {
+	Path   string `json:"path"` // slash-separated, relative to the package
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Archive is a packed package
+type Archive struct {
+	FileName  string `json:"fileName"` // name-version.tgz
+	Index     Index  `json:"index"`
+	Content   []byte `json:"-"`
+	SHA256    string `json:"sha256"`    // hex digest of Content
+	Integrity string `json:"integrity"` // sha512-<base64 digest> of Content, as in Subresource Integrity
+}
+
+// Pack validates the package in dir and packs the files a consumer would
+// install. It fails when the package has validation errors.
+func Pack(dir string) (*Archive, error) {
+	report, err := validate.Run(dir)
+	if err != nil {
+		return nil, err
+	}
+	if errorCount := report.Count(validate.SeverityError); errorCount > 0 {
+		return nil, fmt.Errorf("package has %d validation error(s); run skillmaster validate for details", errorCount)
+	}
+
+	pkg, err := manifest.LoadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Build(dir, pkg, report.Files)
+}
+
+// entry is a file written to an archive
+type entry struct {
+	name    string
+	mode    int64
+	content []byte
+}
+
+// Build packs the given package files, skillmaster-package.json and the
+// index into a gzipped tarball. Entries are sorted and have fixed times,
+// owners and modes, so the same files always produce the same archive.
+func Build(dir string, pkg *manifest.PackageManifest, files []string) (*Archive, error) {
+	files = append([]string{}, files...)
+	sort.Strings(files)
+
+	index := Index{Name: pkg.Name, Version: pkg.Version, Files: []IndexFile{}}
+	var entries []entry
+	for _, file := range append(files, manifest.PackageFileName) {
+		fullPath := filepath.Join(dir, filepath.FromSlash(file))
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		mode := int64(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		entries = append(entries, entry{name: file, mode: mode, content: content})
+		if file != manifest.PackageFileName {
+			sum := sha256.Sum256(content)
+			index.Files = append(index.Files, IndexFile{Path: file, Size: len(content), SHA256: hex.EncodeToString(sum[:])})
+		}
+	}
+
+	indexContent, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", IndexFileName, err)
+	}
+	entries = append(entries, entry{name: IndexFileName, mode: 0644, content: append(indexContent, '\n')})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(Prefix, e.name),
+			Size:     int64(len(e.content)),
+			Mode:     e.mode,
+			ModTime:  modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to pack %s: %w", e.name, err)
+		}
+		if _, err := tw.Write(e.content); err != nil {
+			return nil, fmt.Errorf("failed to pack %s: %w", e.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to pack archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+
+	sum256 := sha256.Sum256(buf.Bytes())
+	sum512 := sha512.Sum512(buf.Bytes())
+	return &Archive{
+		FileName:  fmt.Sprintf("%s-%s.tgz", pkg.Name, pkg.Version),
+		Index:     index,
+		Content:   buf.Bytes(),
+		SHA256:    hex.EncodeToString(sum256[:]),
+		Integrity: "sha512-" + base64.StdEncoding.EncodeToString(sum512[:]),
+	}, nil
+}
+
+// Checksum returns a sha256sum-compatible line for the archive
+func (a *Archive) Checksum() string {
+	return fmt.Sprintf("%s  %s\n", a.SHA256, a.FileName)
+}
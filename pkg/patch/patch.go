@@ -0,0 +1,361 @@
+package patch
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines kept around each change
+const contextLines = 3
+
+const noNewline = "\\ No newline at end of file"
+
+// Hunk is a contiguous block of changes within a file
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	lines    []op
+}
+
+// FileDiff holds the changes of a single file. OldPath is empty for
+// added files and NewPath is empty for deleted files.
+type FileDiff struct {
+	OldPath string
+	NewPath string
+	Hunks   []Hunk
+}
+
+// ConflictError lists the hunks of a file that no longer apply
+type ConflictError struct {
+	Path  string
+	Hunks []Hunk
+}
+
+func (e *ConflictError) Error() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d hunk(s) of %s do not apply:\n", len(e.Hunks), e.Path)
+	for _, hunk := range e.Hunks {
+		hunk.write(&buf)
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// Diff returns the changes between two versions of a file, or nil when
+// they are identical. Pass a nil slice to describe an added or deleted file.
+func Diff(filePath string, old, new []byte) *FileDiff {
+	if bytes.Equal(old, new) && (old == nil) == (new == nil) {
+		return nil
+	}
+
+	diff := &FileDiff{OldPath: filePath, NewPath: filePath}
+	if old == nil {
+		diff.OldPath = ""
+	}
+	if new == nil {
+		diff.NewPath = ""
+	}
+
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	// Positions of each op in the old and new file
+	oldPos := make([]int, len(ops)+1)
+	newPos := make([]int, len(ops)+1)
+	var changes []int
+	for i, o := range ops {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if o.kind != opInsert {
+			oldPos[i+1]++
+		}
+		if o.kind != opDelete {
+			newPos[i+1]++
+		}
+		if o.kind != opEqual {
+			changes = append(changes, i)
+		}
+	}
+
+	// Group changes separated by few unchanged lines into hunks
+	for c := 0; c < len(changes); {
+		first, last := changes[c], changes[c]
+		c++
+		for c < len(changes) && changes[c]-last-1 <= 2*contextLines {
+			last = changes[c]
+			c++
+		}
+
+		start := first - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := last + contextLines + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		hunk := Hunk{
+			OldStart: oldPos[start],
+			OldLines: oldPos[end] - oldPos[start],
+			NewStart: newPos[start],
+			NewLines: newPos[end] - newPos[start],
+			lines:    ops[start:end],
+		}
+		if hunk.OldLines > 0 {
+			hunk.OldStart++
+		}
+		if hunk.NewLines > 0 {
+			hunk.NewStart++
+		}
+		diff.Hunks = append(diff.Hunks, hunk)
+	}
+
+	return diff
+}
+
+// Format renders file diffs as a unified diff
+func Format(diffs []*FileDiff) []byte {
+	var buf bytes.Buffer
+	for _, diff := range diffs {
+		oldPath, newPath := "/dev/null", "/dev/null"
+		if diff.OldPath != "" {
+			oldPath = "a/" + diff.OldPath
+		}
+		if diff.NewPath != "" {
+			newPath = "b/" + diff.NewPath
+		}
+		fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldPath, newPath)
+		for _, hunk := range diff.Hunks {
+			hunk.write(&buf)
+		}
+	}
+	return buf.Bytes()
+}
+
+func (h Hunk) write(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+	for _, line := range h.lines {
+		buf.WriteByte(byte(line.kind))
+		buf.WriteString(line.text)
+		if !strings.HasSuffix(line.text, "\n") {
+			buf.WriteString("\n" + noNewline + "\n")
+		}
+	}
+}
+
+// Parse reads a unified diff
+func Parse(data []byte) ([]*FileDiff, error) {
+	var diffs []*FileDiff
+	var current *FileDiff
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			if i+1 >= len(lines) || !strings.HasPrefix(lines[i+1], "+++ ") {
+				return nil, fmt.Errorf("line %d: expected +++ after ---", i+1)
+			}
+			current = &FileDiff{
+				OldPath: parsePath(line[4:], "a/"),
+				NewPath: parsePath(lines[i+1][4:], "b/"),
+			}
+			diffs = append(diffs, current)
+			i++
+
+		case strings.HasPrefix(line, "@@ "):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: hunk outside of a file", i+1)
+			}
+			hunk, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			// Read lines until both sides of the hunk are complete
+			oldCount, newCount := 0, 0
+			for oldCount < hunk.OldLines || newCount < hunk.NewLines {
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("line %d: unexpected end of hunk", i)
+				}
+				body := lines[i]
+				if body == "" {
+					body = " "
+				}
+				kind := opKind(body[0])
+				switch kind {
+				case opEqual:
+					oldCount++
+					newCount++
+				case opDelete:
+					oldCount++
+				case opInsert:
+					newCount++
+				case '\\':
+					stripNewline(hunk.lines)
+					continue
+				default:
+					return nil, fmt.Errorf("line %d: invalid hunk line", i+1)
+				}
+				hunk.lines = append(hunk.lines, op{kind, body[1:] + "\n"})
+			}
+
+			// A missing newline marker may follow the last line
+			if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\\") {
+				stripNewline(hunk.lines)
+				i++
+			}
+			current.Hunks = append(current.Hunks, *hunk)
+		}
+	}
+
+	return diffs, nil
+}
+
+func parsePath(s, prefix string) string {
+	if i := strings.IndexByte(s, '\t'); i != -1 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+func parseHunkHeader(line string) (*Hunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[3] != "@@" && !strings.HasPrefix(fields[3], "@@") {
+		return nil, fmt.Errorf("invalid hunk header: %s", line)
+	}
+	oldStart, oldLines, err := parseRange(fields[1], "-")
+	if err != nil {
+		return nil, err
+	}
+	newStart, newLines, err := parseRange(fields[2], "+")
+	if err != nil {
+		return nil, err
+	}
+	return &Hunk{OldStart: oldStart, OldLines: oldLines, NewStart: newStart, NewLines: newLines}, nil
+}
+
+func parseRange(s, sign string) (int, int, error) {
+	if !strings.HasPrefix(s, sign) {
+		return 0, 0, fmt.Errorf("invalid hunk range: %s", s)
+	}
+	start, count := s[1:], "1"
+	if i := strings.IndexByte(start, ','); i != -1 {
+		start, count = start[:i], start[i+1:]
+	}
+	a, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk range: %s", s)
+	}
+	b, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk range: %s", s)
+	}
+	return a, b, nil
+}
+
+func stripNewline(lines []op) {
+	if len(lines) > 0 {
+		lines[len(lines)-1].text = strings.TrimSuffix(lines[len(lines)-1].text, "\n")
+	}
+}
+
+// Apply applies a file diff to content. Hunks are located at their
+// recorded position or, when the file moved, at the nearest position
+// where their context matches. Hunks that cannot be placed are returned
+// in a *ConflictError.
+func Apply(content []byte, diff *FileDiff) ([]byte, error) {
+	lines := splitLines(content)
+
+	var result []string
+	var failed []Hunk
+	pos := 0   // next line of content to copy
+	delta := 0 // line offset between recorded and actual positions
+
+	for _, hunk := range diff.Hunks {
+		var old, new []string
+		for _, line := range hunk.lines {
+			if line.kind != opInsert {
+				old = append(old, line.text)
+			}
+			if line.kind != opDelete {
+				new = append(new, line.text)
+			}
+		}
+
+		expected := hunk.OldStart - 1 + delta
+		if hunk.OldLines == 0 {
+			expected = hunk.OldStart + delta
+		}
+		at := locate(lines, old, expected, pos)
+		if at == -1 {
+			failed = append(failed, hunk)
+			continue
+		}
+
+		result = append(result, lines[pos:at]...)
+		result = append(result, new...)
+		pos = at + len(old)
+		delta = at - (expected - delta)
+	}
+	result = append(result, lines[pos:]...)
+
+	if len(failed) > 0 {
+		path := diff.NewPath
+		if path == "" {
+			path = diff.OldPath
+		}
+		return nil, &ConflictError{Path: path, Hunks: failed}
+	}
+	return []byte(strings.Join(result, "")), nil
+}
+
+// locate returns the position nearest to expected, not before min, where
+// lines match want, or -1
+func locate(lines, want []string, expected, min int) int {
+	matches := func(at int) bool {
+		if at < min || at+len(want) > len(lines) {
+			return false
+		}
+		for i, line := range want {
+			if lines[at+i] != line {
+				return false
+			}
+		}
+		return true
+	}
+
+	for offset := 0; offset <= len(lines); offset++ {
+		if matches(expected - offset) {
+			return expected - offset
+		}
+		if offset > 0 && matches(expected+offset) {
+			return expected + offset
+		}
+	}
+	return -1
+}
+
+// splitLines splits content into lines that keep their line endings
+func splitLines(content []byte) []string {
+	var lines []string
+	s := string(content)
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i == -1 {
+			lines = append(lines, s)
+			break
+		}
+		lines = append(lines, s[:i+1])
+		s = s[i+1:]
+	}
+	return lines
+}
@@ -0,0 +1,198 @@
+package publish
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"skillmaster/pkg/git"
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/pack"
+	"skillmaster/pkg/release"
+	"skillmaster/pkg/semver"
+)
+
+// Options configures a publish
+type Options struct {
+	// Repo is the GitHub repository (owner/repo); defaults to the remote's
+	Repo string
+	// Remote is the git remote the tag is pushed to
+	Remote string
+	// Push pushes the tag before the release is created
+	Push bool
+}
+
+// Plan is a validated package ready to be published
+type Plan struct {
+	Owner   string
+	Repo    string
+	Tag     string
+	Version semver.Version
+	Notes   string // from CHANGELOG.md; empty to let GitHub generate them
+	Archive *pack.Archive
+	Remote  string
+	Push    bool
+
+	git *git.Repo
+}
+
+// Result describes a published release
+type Result struct {
+	Release    *github.Release
+	Assets     []string
+	Pushed     bool
+	TopicAdded bool
+}
+
+// Prepare validates and packs the package in dir and checks that the tag of
+// its version exists. Nothing is pushed or published.
+func Prepare(dir string, opts Options) (*Plan, error) {
+	archive, err := pack.Pack(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := manifest.LoadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	version, err := semver.Parse(pkg.Version)
+	if err != nil {
+		return nil, fmt.Errorf("version %q in %s is not a semantic version", pkg.Version, manifest.PackageFileName)
+	}
+	version.Original = ""
+
+	plan := &Plan{Version: version, Tag: "v" + version.String(), Archive: archive, Remote: opts.Remote, Push: opts.Push}
+	if plan.Remote == "" {
+		plan.Remote = "origin"
+	}
+
+	plan.git, err = git.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := plan.git.TagExists(plan.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("tag %s does not exist; run skillmaster version to create it", plan.Tag)
+	}
+
+	// The archive is packed from the working tree, which must be the tagged
+	// commit so that the release matches its tag
+	changes, err := plan.git.Changes()
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		return nil, fmt.Errorf("working tree has uncommitted changes; commit them and tag a new version, or stash them first:\n  %s", strings.Join(changes, "\n  "))
+	}
+	untracked, err := plan.git.UntrackedFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range archive.Index.Files {
+		if slices.Contains(untracked, file.Path) {
+			return nil, fmt.Errorf("%s is not committed; commit it and tag a new version, or remove it first", file.Path)
+		}
+	}
+	head, err := plan.git.CommitOf("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	tagged, err := plan.git.CommitOf(plan.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if head != tagged {
+		return nil, fmt.Errorf("HEAD is not at tag %s; check out the tag or release a new version with skillmaster version", plan.Tag)
+	}
+
+	// Find the GitHub repository
+	repoName := opts.Repo
+	if repoName == "" {
+		remoteURL, err := plan.git.RemoteURL(plan.Remote)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the GitHub repository; pass --repo owner/repo: %w", err)
+		}
+		repoName, err = RepoFromRemote(remoteURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plan.Owner, plan.Repo, err = github.ParseRepoURL(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Notes, err = release.ReleaseNotes(dir, version.String())
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ChecksumFileName is the name of the checksum asset
+func (p *Plan) ChecksumFileName() string {
+	return p.Archive.FileName + ".sha256"
+}
+
+// Execute pushes the tag, creates the GitHub release with the archive and
+// its checksum attached, and makes sure the repository has the package topic
+func (p *Plan) Execute(client *github.Client) (*Result, error) {
+	if _, err := client.GetReleaseByTag(p.Owner, p.Repo, p.Tag); err == nil {
+		return nil, fmt.Errorf("%s/%s already has a release for %s", p.Owner, p.Repo, p.Tag)
+	} else if !errors.Is(err, github.ErrNotFound) {
+		return nil, err
+	}
+
+	result := &Result{}
+	if p.Push {
+		if err := p.git.PushTag(p.Remote, p.Tag); err != nil {
+			return nil, err
+		}
+		result.Pushed = true
+	}
+
+	rel, err := client.CreateRelease(p.Owner, p.Repo, github.ReleaseOptions{
+		TagName:    p.Tag,
+		Name:       p.Tag,
+		Body:       p.Notes,
+		Prerelease: p.Version.Prerelease != "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Release = rel
+
+	if err := client.UploadReleaseAsset(p.Owner, p.Repo, rel.ID, p.Archive.FileName, "application/gzip", p.Archive.Content); err != nil {
+		return nil, err
+	}
+	result.Assets = append(result.Assets, p.Archive.FileName)
+	if err := client.UploadReleaseAsset(p.Owner, p.Repo, rel.ID, p.ChecksumFileName(), "text/plain", []byte(p.Archive.Checksum())); err != nil {
+		return nil, err
+	}
+	result.Assets = append(result.Assets, p.ChecksumFileName())
+
+	result.TopicAdded, err = client.EnsureTopic(p.Owner, p.Repo, github.PackageTopic)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var remotePattern = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// RepoFromRemote returns the owner/repo of a GitHub remote URL such as
+// https://github.com/owner/repo.git or git@github.com:owner/repo.git
+func RepoFromRemote(remoteURL string) (string, error) {
+	match := remotePattern.FindStringSubmatch(remoteURL)
+	if match == nil {
+		return "", fmt.Errorf("remote %s is not a GitHub repository; pass --repo owner/repo", remoteURL)
+	}
+	return match[1] + "/" + match[2], nil
+}
@@ -0,0 +1,272 @@
+package publish
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/pack"
+	"skillmaster/pkg/semver"
+)
+
+// fakeGitHub serves the parts of the GitHub API that Execute uses and
+// records what was sent to it
+type fakeGitHub struct {
+	releaseExists bool
+	topics        []string
+
+	created     map[string]any
+	assets      map[string]string
+	topicsSaved []string
+}
+
+func (f *fakeGitHub) serve(t *testing.T) *github.Client {
+	t.Helper()
+	f.assets = make(map[string]string)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/kit/releases/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		if !f.releaseExists {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]any{"id": 1, "tag_name": r.PathValue("tag")})
+	})
+	mux.HandleFunc("POST /repos/acme/kit/releases", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&f.created); err != nil {
+			t.Errorf("failed to decode release: %v", err)
+		}
+		writeJSON(w, map[string]any{"id": 7, "tag_name": f.created["tag_name"], "html_url": "https://github.com/acme/kit/releases/7"})
+	})
+	mux.HandleFunc("POST /repos/acme/kit/releases/7/assets", func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		f.assets[r.URL.Query().Get("name")] = string(content)
+		writeJSON(w, map[string]any{"id": len(f.assets)})
+	})
+	mux.HandleFunc("GET /repos/acme/kit/topics", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"names": f.topics})
+	})
+	mux.HandleFunc("PUT /repos/acme/kit/topics", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Names []string `json:"names"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode topics: %v", err)
+		}
+		f.topicsSaved = body.Names
+		writeJSON(w, body)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := github.NewClientWithBaseURL("", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func testPlan(t *testing.T, version, notes string) *Plan {
+	t.Helper()
+	v, err := semver.Parse(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Plan{
+		Owner:   "acme",
+		Repo:    "kit",
+		Tag:     "v" + version,
+		Version: v,
+		Notes:   notes,
+		Archive: &pack.Archive{
+			FileName: "kit-" + version + ".tgz",
+			Content:  []byte("archive"),
+			SHA256:   "abc123",
+		},
+	}
+}
+
+func TestExecuteRejectsExistingRelease(t *testing.T) {
+	f := &fakeGitHub{releaseExists: true}
+	client := f.serve(t)
+
+	_, err := testPlan(t, "1.2.0", "").Execute(client)
+	if err == nil || !strings.Contains(err.Error(), "already has a release for v1.2.0") {
+		t.Fatalf("expected an existing release error, got %v", err)
+	}
+	if f.created != nil {
+		t.Error("a release was created")
+	}
+}
+
+func TestExecuteCreatesRelease(t *testing.T) {
+	f := &fakeGitHub{topics: []string{"go"}}
+	client := f.serve(t)
+
+	result, err := testPlan(t, "1.2.0", "").Execute(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.created["tag_name"] != "v1.2.0" {
+		t.Errorf("tag_name = %v, want v1.2.0", f.created["tag_name"])
+	}
+	if f.created["generate_release_notes"] != true {
+		t.Error("release notes are not generated when Notes is empty")
+	}
+	if _, ok := f.created["body"]; ok {
+		t.Errorf("body = %v, want none", f.created["body"])
+	}
+	if f.created["prerelease"] != false {
+		t.Errorf("prerelease = %v, want false", f.created["prerelease"])
+	}
+
+	if got := f.assets["kit-1.2.0.tgz"]; got != "archive" {
+		t.Errorf("archive asset = %q, want %q", got, "archive")
+	}
+	if got := f.assets["kit-1.2.0.tgz.sha256"]; got != "abc123  kit-1.2.0.tgz\n" {
+		t.Errorf("checksum asset = %q", got)
+	}
+	if len(result.Assets) != 2 {
+		t.Errorf("Assets = %v, want the archive and its checksum", result.Assets)
+	}
+
+	if !result.TopicAdded {
+		t.Error("TopicAdded = false, want true")
+	}
+	if strings.Join(f.topicsSaved, ",") != "go,skillmaster-package" {
+		t.Errorf("topics = %v, want go and skillmaster-package", f.topicsSaved)
+	}
+	if result.Pushed {
+		t.Error("Pushed = true without Push")
+	}
+}
+
+func TestExecutePrerelease(t *testing.T) {
+	f := &fakeGitHub{}
+	client := f.serve(t)
+
+	if _, err := testPlan(t, "2.0.0-rc.1", "## Added\n\n- Rules").Execute(client); err != nil {
+		t.Fatal(err)
+	}
+	if f.created["prerelease"] != true {
+		t.Errorf("prerelease = %v, want true", f.created["prerelease"])
+	}
+	if f.created["body"] != "## Added\n\n- Rules" {
+		t.Errorf("body = %q, want the notes", f.created["body"])
+	}
+	if _, ok := f.created["generate_release_notes"]; ok {
+		t.Error("release notes are generated although Notes is set")
+	}
+}
+
+func TestExecuteKeepsExistingTopic(t *testing.T) {
+	f := &fakeGitHub{topics: []string{"skillmaster-package"}}
+	client := f.serve(t)
+
+	result, err := testPlan(t, "1.2.0", "").Execute(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TopicAdded {
+		t.Error("TopicAdded = true, want false")
+	}
+	if f.topicsSaved != nil {
+		t.Errorf("topics were replaced with %v", f.topicsSaved)
+	}
+}
+
+// newTaggedPackage creates a git repository with a committed package whose
+// version is tagged
+func newTaggedPackage(t *testing.T, version string) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	writeFile(t, dir, "skillmaster-package.json", `{"name": "kit", "version": "`+version+`", "description": "Rules for kit"}`)
+	writeFile(t, dir, "rules.md", "# Rules\n\nIndent with tabs.\n")
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "Initial commit")
+	runGit(t, dir, "tag", "-a", "v1.2.0", "-m", "v1.2.0")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	for _, version := range []string{"1.2.0", "v1.2.0"} {
+		dir := newTaggedPackage(t, version)
+
+		plan, err := Prepare(dir, Options{Repo: "acme/kit"})
+		if err != nil {
+			t.Fatalf("version %s: %v", version, err)
+		}
+		if plan.Tag != "v1.2.0" || plan.Owner != "acme" || plan.Repo != "kit" {
+			t.Errorf("version %s: plan = %s %s/%s, want v1.2.0 acme/kit", version, plan.Tag, plan.Owner, plan.Repo)
+		}
+	}
+}
+
+func TestPrepareRefusesUncommittedChanges(t *testing.T) {
+	dir := newTaggedPackage(t, "1.2.0")
+	writeFile(t, dir, "rules.md", "# Rules\n\nIndent with spaces.\n")
+
+	_, err := Prepare(dir, Options{Repo: "acme/kit"})
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Fatalf("expected an uncommitted changes error, got %v", err)
+	}
+}
+
+func TestPrepareRefusesUntrackedFiles(t *testing.T) {
+	dir := newTaggedPackage(t, "1.2.0")
+	writeFile(t, dir, "draft.md", "# Draft\n")
+
+	_, err := Prepare(dir, Options{Repo: "acme/kit"})
+	if err == nil || !strings.Contains(err.Error(), "draft.md is not committed") {
+		t.Fatalf("expected an uncommitted file error, got %v", err)
+	}
+}
+
+func TestPrepareRefusesHeadAfterTag(t *testing.T) {
+	dir := newTaggedPackage(t, "1.2.0")
+	writeFile(t, dir, "rules.md", "# Rules\n\nIndent with spaces.\n")
+	runGit(t, dir, "commit", "-q", "-am", "Use spaces")
+
+	_, err := Prepare(dir, Options{Repo: "acme/kit"})
+	if err == nil || !strings.Contains(err.Error(), "HEAD is not at tag v1.2.0") {
+		t.Fatalf("expected a HEAD is not at tag error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"skillmaster/pkg/git"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/semver"
+)
+
+// ChangelogFileName is the changelog updated on release
+const ChangelogFileName = "CHANGELOG.md"
+
+// Options configures a version bump
+type Options struct {
+	// Part is major, minor or patch, or an explicit version such as 2.0.0
+	Part string
+	// Git commits the changed files and tags the commit
+	Git bool
+	// Changelog releases the Unreleased section of CHANGELOG.md
+	Changelog bool
+	// Message is the commit and tag message; %s is replaced by the version
+	Message string
+	// Date is the release date written to the changelog
+	Date time.Time
+}
+
+// Result describes a version bump
+type Result struct {
+	Previous string
+	Version  string
+	Tag      string   // empty without git
+	Files    []string // files changed, relative to the package directory
+	// ChangelogSkipped explains why an existing changelog was not updated
+	ChangelogSkipped string
+}
+
+// Bump raises the version of the package in dir. With git, the working
+// tree must be clean and the tag must not exist; the changed files are then
+// committed and an annotated vX.Y.Z tag is created.
+func Bump(dir string, opts Options) (*Result, error) {
+	pkgPath := filepath.Join(dir, manifest.PackageFileName)
+	data, err := os.ReadFile(pkgPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s not found; run this in a package directory", manifest.PackageFileName)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", manifest.PackageFileName, err)
+	}
+	pkg, err := manifest.ParsePackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out the new version
+	current, err := semver.Parse(pkg.Version)
+	if err != nil {
+		return nil, fmt.Errorf("version %q in %s is not a semantic version", pkg.Version, manifest.PackageFileName)
+	}
+	next, err := nextVersion(current, opts.Part)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Previous: current.String(), Version: next.String()}
+
+	// Refuse before changing anything
+	var repo *git.Repo
+	if opts.Git {
+		repo, err = git.Open(dir)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := repo.Changes()
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			return nil, fmt.Errorf("working tree has uncommitted changes; commit or stash them first:\n  %s", strings.Join(changes, "\n  "))
+		}
+		result.Tag = "v" + result.Version
+		exists, err := repo.TagExists(result.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("tag %s already exists", result.Tag)
+		}
+	}
+
+	var changelog []byte
+	if opts.Changelog {
+		changelog, result.ChangelogSkipped, err = releaseChangelog(dir, result.Version, opts.Date)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Write the files, remembering their content for a failed commit
+	updated, err := manifest.SetPackageVersion(data, result.Version)
+	if err != nil {
+		return nil, err
+	}
+	originals := map[string][]byte{manifest.PackageFileName: data}
+	writes := map[string][]byte{manifest.PackageFileName: updated}
+	result.Files = []string{manifest.PackageFileName}
+	if changelog != nil {
+		original, err := os.ReadFile(filepath.Join(dir, ChangelogFileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ChangelogFileName, err)
+		}
+		originals[ChangelogFileName] = original
+		writes[ChangelogFileName] = changelog
+		result.Files = append(result.Files, ChangelogFileName)
+	}
+	if err := writeFiles(dir, writes); err != nil {
+		return nil, err
+	}
+
+	if repo != nil {
+		message := opts.Message
+		if message == "" {
+			message = "v%s"
+		}
+		message = strings.ReplaceAll(message, "%s", result.Version)
+		if err := repo.Commit(message, result.Files...); err != nil {
+			// Leave the working tree as it was
+			repo.Unstage(result.Files...)
+			if restoreErr := writeFiles(dir, originals); restoreErr != nil {
+				return nil, fmt.Errorf("%v; restoring the previous version also failed: %w", err, restoreErr)
+			}
+			return nil, err
+		}
+		if err := repo.Tag(result.Tag, message); err != nil {
+			return nil, fmt.Errorf("committed %s but failed to tag it: %w", result.Version, err)
+		}
+	}
+
+	return result, nil
+}
+
+func writeFiles(dir string, files map[string][]byte) error {
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// nextVersion bumps a release part or parses an explicit version, which
+// must be higher than the current one
+func nextVersion(current semver.Version, part string) (semver.Version, error) {
+	switch part {
+	case semver.Major, semver.Minor, semver.Patch:
+		return current.Bump(part)
+	}
+
+	next, err := semver.Parse(part)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid release type: %s (use major, minor, patch or a version such as 1.2.0)", part)
+	}
+	next.Original = ""
+	if next.Compare(current) <= 0 {
+		return semver.Version{}, fmt.Errorf("version %s is not higher than the current version %s", next, current)
+	}
+	return next, nil
+}
+
+var unreleasedHeading = regexp.MustCompile(`(?mi)^##[ \t]+\[?unreleased\]?[ \t]*$`)
+
+// releaseChangelog renames the Unreleased section of CHANGELOG.md to the
+// new version and starts a new empty Unreleased section above it. It returns
+// nil content when there is no changelog, or no Unreleased section and why.
+func releaseChangelog(dir, version string, date time.Time) ([]byte, string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ChangelogFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to read %s: %w", ChangelogFileName, err)
+	}
+
+	loc := unreleasedHeading.FindIndex(content)
+	if loc == nil {
+		return nil, "it has no Unreleased section", nil
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	heading := fmt.Sprintf("## [Unreleased]\n\n## [%s] - %s", version, date.Format("2006-01-02"))
+	updated := append([]byte{}, content[:loc[0]]...)
+	updated = append(updated, heading...)
+	return append(updated, content[loc[1]:]...), "", nil
+}
+
+// ReleaseNotes returns the entries of a version in CHANGELOG.md, or an empty
+// string when there is no changelog or no section for the version
+func ReleaseNotes(dir, version string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ChangelogFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", ChangelogFileName, err)
+	}
+
+	heading := regexp.MustCompile(`(?m)^##[ \t]+\[?v?` + regexp.QuoteMeta(version) + `\]?([ \t].*)?$`)
+	loc := heading.FindIndex(content)
+	if loc == nil {
+		return "", nil
+	}
+	section := content[loc[1]:]
+	if next := regexp.MustCompile(`(?m)^##[ \t]`).FindIndex(section); next != nil {
+		section = section[:next[0]]
+	}
+	return strings.TrimSpace(string(section)), nil
+}
@@ -0,0 +1,164 @@
+package release
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"skillmaster/pkg/manifest"
+)
+
+const testPackage = `{
+    "name": "kit",
+    "version": "1.2.0",
+    "description": "Rules for kit"
+}
+`
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+
+### Added
+- Testing rules
+
+## [1.2.0] - 2026-01-05
+
+- First release
+`
+
+var testDate = time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+// newPackageRepo creates a git repository with a committed package and
+// changelog
+func newPackageRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	writeFile(t, dir, manifest.PackageFileName, testPackage)
+	writeFile(t, dir, ChangelogFileName, testChangelog)
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "Initial commit")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBumpRefusesDirtyTree(t *testing.T) {
+	dir := newPackageRepo(t)
+	writeFile(t, dir, ChangelogFileName, testChangelog+"\n- Draft\n")
+
+	_, err := Bump(dir, Options{Part: "minor", Git: true, Changelog: true, Date: testDate})
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Fatalf("expected an uncommitted changes error, got %v", err)
+	}
+	if got := readFile(t, dir, manifest.PackageFileName); got != testPackage {
+		t.Errorf("%s was changed:\n%s", manifest.PackageFileName, got)
+	}
+}
+
+func TestBumpRefusesExistingTag(t *testing.T) {
+	dir := newPackageRepo(t)
+	runGit(t, dir, "tag", "v1.3.0")
+
+	_, err := Bump(dir, Options{Part: "minor", Git: true, Changelog: true, Date: testDate})
+	if err == nil || !strings.Contains(err.Error(), "tag v1.3.0 already exists") {
+		t.Fatalf("expected an existing tag error, got %v", err)
+	}
+	if got := readFile(t, dir, manifest.PackageFileName); got != testPackage {
+		t.Errorf("%s was changed:\n%s", manifest.PackageFileName, got)
+	}
+}
+
+func TestBumpCommitsAndTags(t *testing.T) {
+	dir := newPackageRepo(t)
+
+	result, err := Bump(dir, Options{Part: "minor", Git: true, Changelog: true, Date: testDate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Previous != "1.2.0" || result.Version != "1.3.0" || result.Tag != "v1.3.0" {
+		t.Errorf("result = %+v, want 1.2.0 → 1.3.0 tagged v1.3.0", result)
+	}
+
+	// Only the version changes; the formatting is kept
+	want := strings.Replace(testPackage, `"1.2.0"`, `"1.3.0"`, 1)
+	if got := readFile(t, dir, manifest.PackageFileName); got != want {
+		t.Errorf("%s =\n%s\nwant\n%s", manifest.PackageFileName, got, want)
+	}
+
+	want = strings.Replace(testChangelog, "## [Unreleased]\n", "## [Unreleased]\n\n## [1.3.0] - 2026-03-14\n", 1)
+	if got := readFile(t, dir, ChangelogFileName); got != want {
+		t.Errorf("%s =\n%s\nwant\n%s", ChangelogFileName, got, want)
+	}
+
+	if got := runGit(t, dir, "cat-file", "-t", "v1.3.0"); got != "tag" {
+		t.Errorf("v1.3.0 is a %s, want an annotated tag", got)
+	}
+	if got := runGit(t, dir, "log", "-1", "--format=%s"); got != "v1.3.0" {
+		t.Errorf("commit message = %q, want v1.3.0", got)
+	}
+	if got := runGit(t, dir, "status", "--porcelain"); got != "" {
+		t.Errorf("working tree is not clean:\n%s", got)
+	}
+}
+
+func TestBumpRestoresFilesWhenCommitFails(t *testing.T) {
+	dir := newPackageRepo(t)
+	writeFile(t, dir, filepath.Join(".git", "hooks", "pre-commit"), "#!/bin/sh\nexit 1\n")
+	if err := os.Chmod(filepath.Join(dir, ".git", "hooks", "pre-commit"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Bump(dir, Options{Part: "patch", Git: true, Changelog: true, Date: testDate}); err == nil {
+		t.Fatal("expected the commit to fail")
+	}
+
+	if got := readFile(t, dir, manifest.PackageFileName); got != testPackage {
+		t.Errorf("%s was not restored:\n%s", manifest.PackageFileName, got)
+	}
+	if got := readFile(t, dir, ChangelogFileName); got != testChangelog {
+		t.Errorf("%s was not restored:\n%s", ChangelogFileName, got)
+	}
+	if got := runGit(t, dir, "status", "--porcelain"); got != "" {
+		t.Errorf("working tree is not clean:\n%s", got)
+	}
+	if got := runGit(t, dir, "tag", "--list"); got != "" {
+		t.Errorf("tags = %s, want none", got)
+	}
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	listPattern    = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+(.*)$`)
+	rulePattern    = regexp.MustCompile(`^[ \t]*([-*_])([ \t]*[-*_]){2,}[ \t]*$`)
+	htmlOnly       = regexp.MustCompile(`^[ \t]*(<[^>]+>[ \t]*)+$`)
+	htmlComment    = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	imagePattern  = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	boldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	escapePattern = regexp.MustCompile(`\x00(\d+)\x00`)
+)
+
+// Markdown formats markdown for a terminal: headings, emphasis, inline code
+// and code blocks are highlighted, list bullets are drawn and links show
+// their target. HTML is dropped. Colors follow fatih/color, so they are
+// left out when the output is not a terminal.
+func Markdown(content []byte) string {
+	text := htmlComment.ReplaceAllString(strings.ReplaceAll(string(content), "\r\n", "\n"), "")
+
+	heading1 := color.New(color.FgCyan, color.Bold, color.Underline)
+	heading2 := color.New(color.FgCyan, color.Bold)
+	heading3 := color.New(color.Bold)
+	faint := color.New(color.Faint)
+
+	var out []string
+	fence := ""
+	blank := true
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Code blocks are indented and kept verbatim
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				continue
+			}
+			out = append(out, "    "+faint.Sprint(line))
+			blank = false
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		// Collapse runs of blank lines, including those left by HTML
+		if trimmed == "" || htmlOnly.MatchString(line) {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		switch {
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			title := inline(match[2])
+			switch len(match[1]) {
+			case 1:
+				out = append(out, heading1.Sprint(title))
+			case 2:
+				out = append(out, heading2.Sprint(title))
+			default:
+				out = append(out, heading3.Sprint(title))
+			}
+		case rulePattern.MatchString(line):
+			out = append(out, faint.Sprint(strings.Repeat("─", 40)))
+		case listPattern.MatchString(line):
+			match := listPattern.FindStringSubmatch(line)
+			out = append(out, match[1]+"  • "+inline(match[2]))
+		case strings.HasPrefix(trimmed, ">"):
+			out = append(out, faint.Sprint("│ ")+inline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))))
+		default:
+			out = append(out, inline(line))
+		}
+	}
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
+
+// inline formats the spans of a line. Code spans are set aside first so
+// that markdown inside them is shown as written.
+func inline(line string) string {
+	var spans []string
+	line = codePattern.ReplaceAllStringFunc(line, func(s string) string {
+		spans = append(spans, color.YellowString("%s", codePattern.FindStringSubmatch(s)[1]))
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	})
+
+	line = imagePattern.ReplaceAllString(line, "[image: $1]")
+	line = linkPattern.ReplaceAllStringFunc(line, func(s string) string {
+		match := linkPattern.FindStringSubmatch(s)
+		if match[1] == match[2] {
+			return color.BlueString("%s", match[2])
+		}
+		return match[1] + " " + color.New(color.Faint).Sprintf("(%s)", match[2])
+	})
+	line = boldPattern.ReplaceAllStringFunc(line, func(s string) string {
+		match := boldPattern.FindStringSubmatch(s)
+		return color.New(color.Bold).Sprint(match[1] + match[2])
+	})
+
+	return escapePattern.ReplaceAllStringFunc(line, func(s string) string {
+		index, _ := strconv.Atoi(escapePattern.FindStringSubmatch(s)[1])
+		return spans[index]
+	})
+}
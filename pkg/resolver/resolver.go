@@ -0,0 +1,416 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/semver"
+)
+
+// RootRequirer names the project's skillmaster.json in requirements
+const RootRequirer = "skillmaster.json"
+
+// maxPasses bounds the number of resolution passes
+const maxPasses = 100
+
+// Source provides the versions of packages and their declared dependencies
+type Source interface {
+	// Versions returns the released versions (tags) of a package
+	Versions(name string) ([]string, error)
+	// DefaultRef returns the ref used when a package has no releases
+	DefaultRef(name string) (string, error)
+	// Dependencies returns the dependencies a package version declares,
+	// mapping package names to version ranges
+	Dependencies(name, version string) (map[string]string, error)
+}
+
+// Requirement is a version range one package (or the project) puts on another
+type Requirement struct {
+	From       string // requiring package as name@version, or RootRequirer
+	Constraint string
+}
+
+// Node is a package selected by the resolution
+type Node struct {
+	Name         string
+	Version      string
+	Dependencies map[string]string // declared dependencies and their ranges
+	Root         bool              // listed in skillmaster.json
+	Requirements []Requirement     // what was required of the package
+}
+
+// Graph is the resolved dependency graph of a project
+type Graph struct {
+	Nodes map[string]*Node
+	Order []string // root packages first, then dependencies breadth-first
+}
+
+// Node returns the resolved package with the given name, or nil
+func (g *Graph) Node(name string) *Node {
+	return g.Nodes[name]
+}
+
+// Dependents returns the packages that depend on the given package
+func (g *Graph) Dependents(name string) []string {
+	var dependents []string
+	for _, other := range g.Order {
+		if _, ok := g.Nodes[other].Dependencies[name]; ok {
+			dependents = append(dependents, other)
+		}
+	}
+	return dependents
+}
+
+// UnsatisfiableError is returned when no version of a package satisfies
+// every range required of it
+type UnsatisfiableError struct {
+	Name         string
+	Requirements []Requirement
+	Available    []string
+}
+
+func (e *UnsatisfiableError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "no version of %s satisfies all requirements:", e.Name)
+	for _, req := range e.Requirements {
+		fmt.Fprintf(&b, "\n  %s requires %s", req.From, req.Constraint)
+	}
+	if len(e.Available) > 0 {
+		fmt.Fprintf(&b, "\navailable versions: %s", strings.Join(e.Available, ", "))
+	} else {
+		fmt.Fprintf(&b, "\n%s has no released versions", e.Name)
+	}
+	return b.String()
+}
+
+// CycleError is returned when packages depend on each other
+type CycleError struct {
+	Path []string // name@version of each package, ending where it started
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " → ")
+}
+
+// Resolve selects one version of every package reachable from the roots
+// such that every range required of it is satisfied. Versions in preferred,
+// typically those of the lock file, are kept while they satisfy the ranges;
+// otherwise the highest matching version is selected.
+func Resolve(roots map[string]string, order []string, preferred map[string]string, source Source) (*Graph, error) {
+	r := &resolver{source: source, preferred: preferred, versions: make(map[string][]semver.Version), refs: make(map[string]string), deps: make(map[string]map[string]string)}
+	selected := make(map[string]string)
+
+	// Select versions until no selection changes: a newly selected version
+	// can declare other dependencies, which changes the requirements
+	for pass := 0; ; pass++ {
+		if pass == maxPasses {
+			return nil, fmt.Errorf("dependency resolution did not settle after %d passes", maxPasses)
+		}
+
+		graph, err := r.collect(roots, order, selected)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := false
+		for _, name := range graph.Order {
+			node := graph.Nodes[name]
+			version, err := r.pick(node)
+			if err != nil {
+				return nil, err
+			}
+			if selected[name] != version {
+				selected[name] = version
+				changed = true
+			}
+		}
+		for name := range selected {
+			if graph.Nodes[name] == nil {
+				delete(selected, name)
+			}
+		}
+		if changed {
+			continue
+		}
+
+		for _, name := range graph.Order {
+			graph.Nodes[name].Version = selected[name]
+		}
+		if err := checkCycles(graph); err != nil {
+			return nil, err
+		}
+		return graph, nil
+	}
+}
+
+// resolver caches what the source returned during a resolution
+type resolver struct {
+	source    Source
+	preferred map[string]string
+	versions  map[string][]semver.Version
+	refs      map[string]string
+	deps      map[string]map[string]string
+}
+
+// collect walks the packages reachable from the roots through the
+// dependencies of the currently selected versions
+func (r *resolver) collect(roots map[string]string, order []string, selected map[string]string) (*Graph, error) {
+	graph := &Graph{Nodes: make(map[string]*Node)}
+	var queue []string
+	add := func(name string, req Requirement) {
+		node, ok := graph.Nodes[name]
+		if !ok {
+			node = &Node{Name: name}
+			graph.Nodes[name] = node
+			graph.Order = append(graph.Order, name)
+			queue = append(queue, name)
+		}
+		node.Requirements = append(node.Requirements, req)
+	}
+
+	for _, name := range order {
+		if constraint, ok := roots[name]; ok {
+			add(name, Requirement{From: RootRequirer, Constraint: constraint})
+			graph.Nodes[name].Root = true
+		}
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		version, ok := selected[name]
+		if !ok {
+			continue
+		}
+
+		deps, err := r.dependencies(name, version)
+		if err != nil {
+			return nil, err
+		}
+		graph.Nodes[name].Dependencies = deps
+
+		names := make([]string, 0, len(deps))
+		for dep := range deps {
+			names = append(names, dep)
+		}
+		sort.Strings(names)
+		for _, dep := range names {
+			add(dep, Requirement{From: name + "@" + version, Constraint: deps[dep]})
+		}
+	}
+	return graph, nil
+}
+
+// pick selects the version of a package that satisfies its requirements
+func (r *resolver) pick(node *Node) (string, error) {
+	// A requirement naming a branch or commit selects it directly
+	var ranges []*semver.Constraint
+	ref := ""
+	for _, req := range node.Requirements {
+		constraint, isRef, err := ParseRequirement(req.Constraint)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid requirement on %s: %w", req.From, node.Name, err)
+		}
+		if isRef {
+			if ref != "" && ref != req.Constraint {
+				return "", r.unsatisfiable(node)
+			}
+			ref = req.Constraint
+			continue
+		}
+		ranges = append(ranges, constraint)
+	}
+	if ref != "" {
+		if len(ranges) > 0 {
+			// A branch has no version to check a range against
+			return "", r.unsatisfiable(node)
+		}
+		return ref, nil
+	}
+
+	// Keep the preferred version while it satisfies every range
+	if preferred, err := semver.Parse(r.preferred[node.Name]); err == nil && satisfiesAll(ranges, preferred) {
+		return preferred.String(), nil
+	}
+
+	versions, err := r.releases(node.Name)
+	if err != nil {
+		return "", err
+	}
+
+	// Packages without releases are installed from their default branch
+	// when nothing more specific is required
+	if len(versions) == 0 && unconstrained(ranges) {
+		return r.defaultRef(node.Name)
+	}
+
+	for _, v := range versions {
+		if satisfiesAll(ranges, v) {
+			return v.String(), nil
+		}
+	}
+	return "", r.unsatisfiable(node)
+}
+
+// satisfiesAll reports whether a version satisfies every range
+func satisfiesAll(ranges []*semver.Constraint, v semver.Version) bool {
+	for _, constraint := range ranges {
+		if !constraint.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *resolver) unsatisfiable(node *Node) error {
+	err := &UnsatisfiableError{Name: node.Name, Requirements: node.Requirements}
+	for _, v := range r.versions[node.Name] {
+		err.Available = append(err.Available, v.String())
+	}
+	return err
+}
+
+// defaultRef returns the default branch of a package
+func (r *resolver) defaultRef(name string) (string, error) {
+	if ref, ok := r.refs[name]; ok {
+		return ref, nil
+	}
+	ref, err := r.source.DefaultRef(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the default branch of %s: %w", name, err)
+	}
+	r.refs[name] = ref
+	return ref, nil
+}
+
+// releases returns the semantic versions of a package, highest first
+func (r *resolver) releases(name string) ([]semver.Version, error) {
+	if versions, ok := r.versions[name]; ok {
+		return versions, nil
+	}
+
+	tags, err := r.source.Versions(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of %s: %w", name, err)
+	}
+	versions := []semver.Version{}
+	for _, tag := range tags {
+		if v, err := semver.Parse(tag); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	semver.Sort(versions)
+	r.versions[name] = versions
+	return versions, nil
+}
+
+// dependencies returns the dependencies of a package version
+func (r *resolver) dependencies(name, version string) (map[string]string, error) {
+	key := name + "@" + version
+	if deps, ok := r.deps[key]; ok {
+		return deps, nil
+	}
+	deps, err := r.source.Dependencies(name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the dependencies of %s: %w", key, err)
+	}
+	r.deps[key] = deps
+	return deps, nil
+}
+
+// ParseRequirement parses a required version. Anything that is not a
+// version range, such as a branch name, is a git ref and returned with
+// isRef set.
+func ParseRequirement(s string) (constraint *semver.Constraint, isRef bool, err error) {
+	constraint, err = semver.ParseConstraint(s)
+	if err == nil {
+		return constraint, false, nil
+	}
+	if strings.ContainsAny(s, " <>=^~|*,") {
+		return nil, false, err
+	}
+	return nil, true, nil
+}
+
+// Satisfies reports whether a version meets a requirement: a version range
+// or a git ref that must match exactly
+func Satisfies(requirement, version string) bool {
+	constraint, isRef, err := ParseRequirement(requirement)
+	switch {
+	case err != nil:
+		return false
+	case isRef:
+		return requirement == version
+	}
+	if v, err := semver.Parse(version); err == nil {
+		return constraint.Check(v)
+	}
+	// A branch installed for an unconstrained package without releases
+	return unconstrained([]*semver.Constraint{constraint})
+}
+
+// unconstrained reports whether the ranges match every release
+func unconstrained(ranges []*semver.Constraint) bool {
+	for _, constraint := range ranges {
+		switch constraint.String() {
+		case "*", "latest", "x", "X":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkCycles returns a CycleError for the first dependency cycle found
+func checkCycles(graph *Graph) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			start := 0
+			for i, other := range stack {
+				if other == name {
+					start = i
+				}
+			}
+			var path []string
+			for _, other := range append(stack[start:], name) {
+				path = append(path, other+"@"+graph.Nodes[other].Version)
+			}
+			return &CycleError{Path: path}
+		case done:
+			return nil
+		}
+
+		state[name] = visiting
+		stack = append(stack, name)
+		deps := make([]string, 0, len(graph.Nodes[name].Dependencies))
+		for dep := range graph.Nodes[name].Dependencies {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range graph.Order {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/manifest"
+)
+
+//go:embed all:templates
+var templateFS embed.FS
+
+// Template is a built-in package layout
+type Template struct {
+	Name        string
+	Description string
+	Keywords    []string
+	Assets      []string // non-markdown files the template ships
+}
+
+// templates lists the built-in templates; the first is the default
+var templates = []Template{
+	{
+		Name:        "language-guide",
+		Description: "Style guide and prompts for a programming language",
+		Keywords:    []string{"style-guide", "conventions"},
+	},
+	{
+		Name:        "framework-patterns",
+		Description: "Patterns and feature prompts for a framework",
+		Keywords:    []string{"patterns", "best-practices"},
+	},
+	{
+		Name:        "code-review",
+		Description: "Code review and security checklists",
+		Keywords:    []string{"code-review", "checklist"},
+	},
+	{
+		Name:        "claude-skill",
+		Description: "Claude skill with SKILL.md, reference docs and a helper script",
+		Keywords:    []string{"claude", "skills"},
+		Assets:      []string{"skills/*/scripts"},
+	},
+}
+
+// Templates returns the built-in templates
+func Templates() []Template {
+	return append([]Template{}, templates...)
+}
+
+// DefaultTemplate is used when no template is given
+func DefaultTemplate() string {
+	return templates[0].Name
+}
+
+// Lookup returns the built-in template with the given name
+func Lookup(name string) (Template, bool) {
+	for _, t := range templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Options describes the package to create
+type Options struct {
+	Name        string
+	Description string
+	Author      string
+	Template    string
+}
+
+// templateData is available to template files
+type templateData struct {
+	Name        string
+	Description string
+	Author      string
+	Repo        string // owner/name, for install instructions
+	Topic       string
+}
+
+// Create writes a new package into dir, which must not exist or be empty.
+// It returns the created files, slash-separated and relative to dir.
+func Create(dir string, opts Options) ([]string, error) {
+	if !manifest.ValidPackageName(opts.Name) {
+		return nil, fmt.Errorf("invalid package name: %s (use letters, digits, '.', '-' and '_')", opts.Name)
+	}
+	if opts.Template == "" {
+		opts.Template = DefaultTemplate()
+	}
+	tmpl, ok := Lookup(opts.Template)
+	if !ok {
+		var names []string
+		for _, t := range templates {
+			names = append(names, t.Name)
+		}
+		return nil, fmt.Errorf("unknown template: %s (available: %s)", opts.Template, strings.Join(names, ", "))
+	}
+	if opts.Description == "" {
+		opts.Description = tmpl.Description
+	}
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("directory %s already exists and is not empty", dir)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	owner := opts.Author
+	if owner == "" || strings.ContainsAny(owner, " /") {
+		owner = "your-username"
+	}
+	data := templateData{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Author:      opts.Author,
+		Repo:        owner + "/" + opts.Name,
+		Topic:       github.PackageTopic,
+	}
+
+	// Render the template files
+	root := path.Join("templates", tmpl.Name)
+	var created []string
+	err := fs.WalkDir(templateFS, root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := renderFile(templateFS, filePath, data)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(filePath, root+"/")
+		if err := writeFile(dir, rel, content); err != nil {
+			return err
+		}
+		created = append(created, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Describe the package
+	pkgManifest := manifest.PackageManifest{
+		Name:        opts.Name,
+		Version:     "0.1.0",
+		Description: opts.Description,
+		Author:      opts.Author,
+		License:     "MIT",
+		Keywords:    tmpl.Keywords,
+		Assets:      tmpl.Assets,
+	}
+	content, err := json.MarshalIndent(pkgManifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", manifest.PackageFileName, err)
+	}
+	if err := writeFile(dir, manifest.PackageFileName, append(content, '\n')); err != nil {
+		return nil, err
+	}
+	created = append(created, manifest.PackageFileName)
+
+	return created, nil
+}
+
+// renderFile executes a template file with the package data
+func renderFile(fsys fs.FS, filePath string, data templateData) ([]byte, error) {
+	source, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", filePath, err)
+	}
+	t, err := template.New(path.Base(filePath)).Option("missingkey=error").Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", filePath, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", filePath, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile writes a file below dir, creating parent directories.
+// Scripts are made executable.
+func writeFile(dir, rel string, content []byte) error {
+	fullPath := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+	}
+	mode := os.FileMode(0644)
+	if path.Ext(rel) == ".sh" {
+		mode = 0755
+	}
+	if err := os.WriteFile(fullPath, content, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", rel, err)
+	}
+	return nil
+}
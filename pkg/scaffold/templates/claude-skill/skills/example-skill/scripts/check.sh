@@ -0,0 +1,4 @@
+#!/bin/sh
+# Reports common problems in the current project
+[ -f README.md ] || echo "missing-readme"
+grep -rn "TODO" --include='*.*' . 2>/dev/null | sed 's/^/todo: /' | head -20
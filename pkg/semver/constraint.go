@@ -0,0 +1,231 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a version range such as ^1.2.0, ~1.2, >=1.0.0 <2.0.0,
+// 1.x or 1.2.3 || ^2.0.0. Alternatives are separated by ||; the
+// comparators of one alternative must all match.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+// comparator compares a version with a bound
+type comparator struct {
+	op      string // =, <, <=, > or >=
+	version Version
+}
+
+// ParseConstraint parses a version range. An empty range, * and latest
+// match every release.
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	for _, alternative := range strings.Split(c.raw, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+
+		// Operators separated from their version: >= 1.0.0
+		var terms []string
+		for i := 0; i < len(fields); i++ {
+			if strings.Trim(fields[i], "<>=~^") == "" && i+1 < len(fields) {
+				terms = append(terms, fields[i]+fields[i+1])
+				i++
+				continue
+			}
+			terms = append(terms, fields[i])
+		}
+
+		set := []comparator{}
+		for _, term := range terms {
+			comparators, err := parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range %q: %w", c.raw, err)
+			}
+			set = append(set, comparators...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// String returns the range as it was written
+func (c *Constraint) String() string {
+	if c.raw == "" {
+		return "*"
+	}
+	return c.raw
+}
+
+// Check reports whether a version satisfies the range. Prereleases only
+// match a range that names a prerelease of the same version.
+func (c *Constraint) Check(v Version) bool {
+	for _, set := range c.sets {
+		if matchesSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSet(set []comparator, v Version) bool {
+	for _, cmp := range set {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	if v.Prerelease == "" {
+		return true
+	}
+	for _, cmp := range set {
+		bound := cmp.version
+		if bound.Prerelease != "" && bound.Major == v.Major && bound.Minor == v.Minor && bound.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmp comparator) matches(v Version) bool {
+	result := v.Compare(cmp.version)
+	switch cmp.op {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return result == 0
+}
+
+// parseTerm expands one term of a range into comparators
+func parseTerm(term string) ([]comparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+
+	rest := strings.TrimPrefix(term, op)
+	if op != "" && rest == "" {
+		return nil, fmt.Errorf("missing version after %s", op)
+	}
+	p, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+	if p.parts == 0 {
+		// * matches everything, and so does any comparison with it
+		return nil, nil
+	}
+	low := p.version()
+
+	switch op {
+	case "", "=":
+		if p.parts == 3 {
+			return []comparator{{"=", low}}, nil
+		}
+		return between(low, p.next(p.parts)), nil
+	case "^":
+		// Changes left of the first non-zero component are breaking
+		switch {
+		case low.Major > 0 || p.parts == 1:
+			return between(low, p.next(1)), nil
+		case low.Minor > 0 || p.parts == 2:
+			return between(low, p.next(2)), nil
+		}
+		return between(low, p.next(3)), nil
+	case "~":
+		if p.parts == 1 {
+			return between(low, p.next(1)), nil
+		}
+		return between(low, p.next(2)), nil
+	case ">":
+		if p.parts == 3 {
+			return []comparator{{">", low}}, nil
+		}
+		next := p.next(p.parts)
+		next.Prerelease = ""
+		return []comparator{{">=", next}}, nil
+	case "<=":
+		if p.parts == 3 {
+			return []comparator{{"<=", low}}, nil
+		}
+		return []comparator{{"<", p.next(p.parts)}}, nil
+	}
+	return []comparator{{op, low}}, nil
+}
+
+// between returns the comparators of the range [low, high)
+func between(low, high Version) []comparator {
+	return []comparator{{">=", low}, {"<", high}}
+}
+
+// partial is a version with trailing components omitted or wildcards
+type partial struct {
+	numbers    [3]int
+	parts      int // number of given components
+	prerelease string
+}
+
+func parsePartial(s string) (partial, error) {
+	var p partial
+	s = strings.TrimPrefix(s, "v")
+	if s == "" || s == "*" || s == "x" || s == "X" || s == "latest" {
+		return p, nil
+	}
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, p.prerelease = s[:i], s[i+1:]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("invalid version: %s", s)
+	}
+	for i, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			break
+		}
+		n, err := parseNumber(part)
+		if err != nil {
+			return p, fmt.Errorf("invalid version: %s", s)
+		}
+		p.numbers[i] = n
+		p.parts++
+	}
+	if p.prerelease != "" && p.parts < 3 {
+		return p, fmt.Errorf("invalid version: %s", s)
+	}
+	return p, nil
+}
+
+// version returns the lowest version the partial version matches
+func (p partial) version() Version {
+	return Version{Major: p.numbers[0], Minor: p.numbers[1], Patch: p.numbers[2], Prerelease: p.prerelease}
+}
+
+// next returns the first release after the partial version when it is
+// truncated to the given number of components, as a lower prerelease bound
+// so that prereleases of the next version are excluded
+func (p partial) next(parts int) Version {
+	v := Version{Prerelease: "0"}
+	switch parts {
+	case 1:
+		v.Major = p.numbers[0] + 1
+	case 2:
+		v.Major, v.Minor = p.numbers[0], p.numbers[1]+1
+	default:
+		v.Major, v.Minor, v.Patch = p.numbers[0], p.numbers[1], p.numbers[2]+1
+	}
+	return v
+}
@@ -0,0 +1,178 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version. Tags such as v1.2.3 are accepted and keep
+// their original spelling so they can be used as git refs.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Original   string
+}
+
+// Parse parses a version such as 1.2.3, v1.2.3 or 1.2.3-beta.1+build
+func Parse(s string) (Version, error) {
+	original := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	var prerelease string
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, prerelease = s[:i], s[i+1:]
+		if prerelease == "" {
+			return Version{}, fmt.Errorf("invalid version: %s", original)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version: %s", original)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := parseNumber(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version: %s", original)
+		}
+		numbers[i] = n
+	}
+
+	return Version{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Prerelease: prerelease,
+		Original:   original,
+	}, nil
+}
+
+// String returns the version as it was written
+func (v Version) String() string {
+	if v.Original != "" {
+		return v.Original
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Release parts that can be bumped
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+)
+
+// Bump returns the next release after v. A prerelease is released as the
+// version it precedes when that is the requested kind of release, so
+// 1.3.0-beta.1 bumps to 1.3.0 for a minor release but to 2.0.0 for a major one.
+func (v Version) Bump(part string) (Version, error) {
+	next := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch part {
+	case Major:
+		if v.Prerelease == "" || v.Minor != 0 || v.Patch != 0 {
+			next = Version{Major: v.Major + 1}
+		}
+	case Minor:
+		if v.Prerelease == "" || v.Patch != 0 {
+			next = Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	case Patch:
+		if v.Prerelease == "" {
+			next.Patch++
+		}
+	default:
+		return Version{}, fmt.Errorf("invalid release type: %s (use major, minor or patch)", part)
+	}
+	return next, nil
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or higher than o.
+// A prerelease is lower than the release it precedes.
+func (v Version) Compare(o Version) int {
+	switch {
+	case v.Major != o.Major:
+		return compareInts(v.Major, o.Major)
+	case v.Minor != o.Minor:
+		return compareInts(v.Minor, o.Minor)
+	case v.Patch != o.Patch:
+		return compareInts(v.Patch, o.Patch)
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// Sort sorts versions from highest to lowest
+func Sort(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) > 0
+	})
+}
+
+// comparePrerelease compares dot-separated prerelease identifiers,
+// numerically when both are numbers
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return compareInts(an, bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return compareInts(len(as), len(bs))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseNumber parses a version component without sign or leading zeros
+func parseNumber(s string) (int, error) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, fmt.Errorf("invalid number: %s", s)
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid number: %s", s)
+		}
+	}
+	return strconv.Atoi(s)
+}
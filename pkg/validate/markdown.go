@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"skillmaster/pkg/frontmatter"
+	"skillmaster/pkg/installer"
+)
+
+// checkFrontmatter reports frontmatter that cannot be parsed
+func (c *checker) checkFrontmatter(file string, content []byte) (*frontmatter.Frontmatter, bool) {
+	fm, _, err := frontmatter.Parse(content)
+	if err != nil {
+		var syntaxErr *frontmatter.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			c.add(file, syntaxErr.Line, SeverityError, "invalid frontmatter: %s", syntaxErr.Msg)
+		} else {
+			c.add(file, 1, SeverityError, "invalid frontmatter: %v", err)
+		}
+		return nil, false
+	}
+	return fm, true
+}
+
+// checkSkill validates the frontmatter of a Claude skill
+func (c *checker) checkSkill(file string, content []byte) {
+	fm, ok := c.checkFrontmatter(file, content)
+	if !ok {
+		return
+	}
+
+	dir := path.Dir(file)
+	fallback := path.Base(dir)
+	if dir == "." {
+		fallback = "the repository name"
+	}
+	switch name := fm.String("name"); {
+	case name == "":
+		c.add(file, 1, SeverityWarning, "name is missing; the skill will be named after %s", fallback)
+	case installer.SkillName(name) != name:
+		c.add(file, fm.Line("name"), SeverityError, "name %q must be lowercase letters, digits and hyphens, at most 64 characters", name)
+	default:
+		if other, ok := c.skills[name]; ok {
+			c.add(file, fm.Line("name"), SeverityError, "skill name %q is also used by %s", name, other)
+		}
+		c.skills[name] = file
+	}
+
+	switch description := strings.TrimSpace(fm.String("description")); {
+	case description == "":
+		c.add(file, 1, SeverityWarning, "description is missing; Claude uses it to decide when to load the skill")
+	case utf8.RuneCountInString(description) > installer.MaxSkillDescriptionSize:
+		c.add(file, fm.Line("description"), SeverityWarning, "description is longer than %d characters and will be truncated", installer.MaxSkillDescriptionSize)
+	}
+}
+
+// checkRule validates the frontmatter of a Cursor rule
+func (c *checker) checkRule(file string, content []byte) {
+	fm, ok := c.checkFrontmatter(file, content)
+	if !ok {
+		return
+	}
+
+	alwaysApply := false
+	if fm.Has("alwaysApply") {
+		value, ok := fm.Bool("alwaysApply")
+		if !ok {
+			c.add(file, fm.Line("alwaysApply"), SeverityError, "alwaysApply must be true or false")
+		}
+		alwaysApply = value
+	}
+	if !alwaysApply && fm.String("description") == "" && len(fm.Strings("globs")) == 0 {
+		c.add(file, 1, SeverityWarning, "rule has no description, globs or alwaysApply; the installed rule gets a description from its first paragraph")
+	}
+}
+
+var (
+	// inlineLink matches [text](target) and ![alt](target)
+	inlineLink = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// referenceLink matches a [label]: target definition
+	referenceLink = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s|$)`)
+	inlineCode    = regexp.MustCompile("`[^`]*`")
+)
+
+// checkLinks reports relative links to files that are missing, outside the
+// package or not installed with it
+func (c *checker) checkLinks(file string, content []byte) {
+	inFence := false
+	for i, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		line = inlineCode.ReplaceAllString(line, "")
+		var targets []string
+		for _, match := range inlineLink.FindAllStringSubmatch(line, -1) {
+			targets = append(targets, match[1])
+		}
+		if match := referenceLink.FindStringSubmatch(line); match != nil {
+			targets = append(targets, match[1])
+		}
+		for _, target := range targets {
+			c.checkLink(file, i+1, target)
+		}
+	}
+}
+
+func (c *checker) checkLink(file string, line int, target string) {
+	if i := strings.IndexAny(target, "#?"); i >= 0 {
+		target = target[:i]
+	}
+	if target == "" {
+		return // anchor in the same file
+	}
+	if u, err := url.Parse(target); err == nil && u.Scheme != "" {
+		return // URL, mailto and other schemes
+	}
+	if strings.HasPrefix(target, "//") {
+		return
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+
+	var resolved string
+	if strings.HasPrefix(target, "/") {
+		resolved = path.Clean(strings.TrimPrefix(target, "/"))
+	} else {
+		resolved = path.Join(path.Dir(file), target)
+	}
+	switch {
+	case resolved == ".." || strings.HasPrefix(resolved, "../"):
+		c.add(file, line, SeverityError, "link %s points outside the package", target)
+	case resolved == ".":
+	case c.exists(resolved):
+		if !c.included[resolved] {
+			c.add(file, line, SeverityWarning, "link %s points to %s, which is not installed with the package", target, resolved)
+		}
+	case !c.isDir(resolved):
+		c.add(file, line, SeverityError, "broken link %s: %s does not exist", target, resolved)
+	}
+}
+
+// exists reports whether a file of the repository has the given path
+func (c *checker) exists(filePath string) bool {
+	for _, f := range c.all {
+		if f == filePath {
+			return true
+		}
+	}
+	return false
+}
+
+// isDir reports whether the path is a directory containing repository files
+func (c *checker) isDir(filePath string) bool {
+	for _, f := range c.all {
+		if strings.HasPrefix(f, filePath+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,363 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/glob"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lint"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/resolver"
+	"skillmaster/pkg/semver"
+)
+
+// Severities of problems
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem is an issue found in a package
+type Problem struct {
+	File     string `json:"file"` // slash-separated, relative to the package root
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the problem as file:line: severity: message
+func (p Problem) String() string {
+	location := p.File
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, p.Severity, p.Message)
+}
+
+// Report lists the problems found in a package
+type Report struct {
+	Files    []string  `json:"files"` // files that would be installed
+	Problems []Problem `json:"problems"`
+}
+
+// Count returns the number of problems with the given severity
+func (r *Report) Count(severity string) int {
+	n := 0
+	for _, p := range r.Problems {
+		if p.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// checker collects the problems of one package
+type checker struct {
+	dir      string
+	report   *Report
+	all      []string          // every file in the repository
+	included map[string]bool   // files that would be installed
+	skills   map[string]string // skill names and the SKILL.md using them
+}
+
+func (c *checker) add(file string, line int, severity, format string, args ...interface{}) {
+	c.report.Problems = append(c.report.Problems, Problem{File: file, Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// Run validates the package in dir: its skillmaster-package.json, the
+// files it selects, the frontmatter of skills and Cursor rules, and the
+// relative links between its markdown files
+func Run(dir string) (*Report, error) {
+	c := &checker{dir: dir, report: &Report{Files: []string{}, Problems: []Problem{}}, included: make(map[string]bool), skills: make(map[string]string)}
+
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	c.all = files
+
+	data, err := os.ReadFile(filepath.Join(dir, manifest.PackageFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", manifest.PackageFileName, err)
+		}
+		c.add(manifest.PackageFileName, 0, SeverityError, "file not found; run skillmaster create or add one describing the package")
+		return c.report, nil
+	}
+
+	pkg := c.checkManifest(data)
+	if pkg == nil {
+		return c.report, nil
+	}
+	c.checkFiles(pkg, data)
+
+	for _, file := range c.report.Files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		switch {
+		case strings.EqualFold(path.Base(file), "SKILL.md"):
+			c.checkSkill(file, content)
+		case strings.EqualFold(path.Ext(file), ".mdc"):
+			c.checkRule(file, content)
+		case strings.EqualFold(path.Ext(file), ".md"):
+			c.checkFrontmatter(file, content)
+		}
+		if ext := strings.ToLower(path.Ext(file)); ext == ".md" || ext == ".mdc" {
+			c.checkLinks(file, content)
+		}
+	}
+
+	sort.SliceStable(c.report.Problems, func(i, j int) bool {
+		a, b := c.report.Problems[i], c.report.Problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return c.report, nil
+}
+
+// listFiles returns the files of a package directory, skipping hidden files
+// and directories as installs do
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list package files: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// checkManifest validates skillmaster-package.json against its schema.
+// It returns nil when the file cannot be parsed at all.
+func (c *checker) checkManifest(data []byte) *manifest.PackageManifest {
+	const file = manifest.PackageFileName
+
+	var pkg manifest.PackageManifest
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			c.add(file, lineAt(data, syntaxErr.Offset), SeverityError, "invalid JSON: %v", err)
+		case errors.As(err, &typeErr):
+			c.add(file, lineAt(data, typeErr.Offset), SeverityError, "%s must be %s, not %s", typeErr.Field, describeType(typeErr.Type.String()), typeErr.Value)
+		default:
+			c.add(file, 0, SeverityError, "invalid JSON: %v", err)
+		}
+		return nil
+	}
+
+	// Unknown fields are usually typos of known ones
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest.PackageManifest{}); err != nil {
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		c.add(file, keyLine(data, field), SeverityError, "unknown field %q", field)
+	}
+
+	switch {
+	case pkg.Name == "":
+		c.add(file, 0, SeverityError, "name is required")
+	case !manifest.ValidPackageName(pkg.Name):
+		c.add(file, keyLine(data, "name"), SeverityError, "name %q must only contain letters, digits, '.', '-' and '_'", pkg.Name)
+	}
+
+	if pkg.Version == "" {
+		c.add(file, 0, SeverityError, "version is required")
+	} else if _, err := semver.Parse(pkg.Version); err != nil {
+		c.add(file, keyLine(data, "version"), SeverityError, "version %q is not a semantic version such as 1.2.0", pkg.Version)
+	}
+
+	if pkg.Description == "" {
+		c.add(file, 0, SeverityWarning, "description is missing; it is shown by search and list")
+	}
+
+	for _, group := range []struct {
+		key      string
+		patterns []string
+	}{{"files", pkg.Files}, {"exclude", pkg.Exclude}, {"assets", pkg.Assets}} {
+		for _, pattern := range group.patterns {
+			if !glob.Valid(pattern) {
+				c.add(file, valueLine(data, group.key, pattern), SeverityError, "invalid glob in %s: %s", group.key, pattern)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(pkg.Dependencies))
+	for name := range pkg.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		line := valueLine(data, "dependencies", name)
+		if parts := strings.Split(name, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			c.add(file, line, SeverityError, "dependency %q must be owner/repo", name)
+			continue
+		}
+		if _, _, err := resolver.ParseRequirement(pkg.Dependencies[name]); err != nil {
+			c.add(file, line, SeverityError, "dependency %s: %v", name, err)
+		}
+	}
+
+	if _, err := lint.New(pkg.Lint); err != nil {
+		c.add(file, keyLine(data, "lint"), SeverityError, "%v", err)
+	}
+
+	return &pkg
+}
+
+// checkFiles selects the files an install would download and checks that
+// every glob matches something
+func (c *checker) checkFiles(pkg *manifest.PackageManifest, data []byte) {
+	const file = manifest.PackageFileName
+
+	for _, pattern := range pkg.Files {
+		if glob.Valid(pattern) && !c.anyMatch(pattern, isMarkdown) {
+			c.add(file, valueLine(data, "files", pattern), SeverityError, "files glob %q matches no markdown files", pattern)
+		}
+	}
+	for _, pattern := range pkg.Exclude {
+		if glob.Valid(pattern) && !c.anyMatch(pattern, func(string) bool { return true }) {
+			c.add(file, valueLine(data, "exclude", pattern), SeverityWarning, "exclude glob %q matches no files", pattern)
+		}
+	}
+	for _, pattern := range pkg.Assets {
+		if glob.Valid(pattern) && !c.anyMatch(pattern, func(f string) bool { return !isMarkdown(f) }) {
+			c.add(file, valueLine(data, "assets", pattern), SeverityError, "assets glob %q matches no files", pattern)
+		}
+	}
+
+	total := 0
+	for _, f := range c.all {
+		switch {
+		case isMarkdown(f):
+			if !pkg.Includes(f) {
+				continue
+			}
+		case pkg.IncludesAsset(f):
+			info, err := os.Stat(filepath.Join(c.dir, filepath.FromSlash(f)))
+			if err != nil {
+				continue
+			}
+			size := int(info.Size())
+			switch {
+			case !installer.AllowedAsset(f):
+				c.add(f, 0, SeverityError, "file type is not allowed as an asset and will not be installed")
+				continue
+			case size > installer.MaxAssetSize:
+				c.add(f, 0, SeverityError, "asset is larger than %d KB and will not be installed", installer.MaxAssetSize/1024)
+				continue
+			case total+size > installer.MaxAssetsTotal:
+				c.add(f, 0, SeverityError, "assets exceed %d MB in total; this file will not be installed", installer.MaxAssetsTotal/(1024*1024))
+				continue
+			}
+			total += size
+		default:
+			continue
+		}
+		c.included[f] = true
+		c.report.Files = append(c.report.Files, f)
+	}
+
+	hasMarkdown := false
+	for _, f := range c.report.Files {
+		if isMarkdown(f) {
+			hasMarkdown = true
+		}
+	}
+	if !hasMarkdown {
+		c.add(file, 0, SeverityError, "the package installs no markdown files")
+	}
+}
+
+// anyMatch reports whether a glob matches one of the repository files
+// accepted by filter
+func (c *checker) anyMatch(pattern string, filter func(string) bool) bool {
+	for _, f := range c.all {
+		if filter(f) && manifest.MatchGlob(pattern, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMarkdown(filePath string) bool {
+	return strings.EqualFold(path.Ext(filePath), ".md")
+}
+
+// lineAt returns the 1-based line of a byte offset
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// keyLine returns the line of a JSON key, or 0 when it is not found
+func keyLine(data []byte, key string) int {
+	i := bytes.Index(data, []byte(`"`+key+`"`))
+	if i < 0 {
+		return 0
+	}
+	return lineAt(data, int64(i))
+}
+
+// valueLine returns the line of a string inside the value of a JSON key,
+// falling back to the line of the key
+func valueLine(data []byte, key, value string) int {
+	start := bytes.Index(data, []byte(`"`+key+`"`))
+	if start < 0 {
+		return 0
+	}
+	quoted, _ := json.Marshal(value)
+	i := bytes.Index(data[start:], quoted)
+	if i < 0 {
+		return lineAt(data, int64(start))
+	}
+	return lineAt(data, int64(start+i))
+}
+
+// describeType names a Go type in JSON terms
+func describeType(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		return "an array"
+	case strings.HasPrefix(goType, "map["):
+		return "an object"
+	case goType == "string":
+		return "a string"
+	}
+	return goType
+}
@@ -0,0 +1,232 @@
+package verify
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillmaster/pkg/github"
+	"skillmaster/pkg/installer"
+	"skillmaster/pkg/lockfile"
+	"skillmaster/pkg/manifest"
+	"skillmaster/pkg/resolver"
+)
+
+// PackageReport lists the differences found for one package
+type PackageReport struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version,omitempty"` // locked version
+	Files      int      `json:"files"`
+	Modified   []string `json:"modified"`
+	Missing    []string `json:"missing"`
+	Extra      []string `json:"extra"`
+	Unverified []string `json:"unverified,omitempty"` // installed before hashes were recorded
+	Issues     []string `json:"issues"`
+}
+
+// OK reports whether the package matches the lock file
+func (p *PackageReport) OK() bool {
+	return len(p.Modified) == 0 && len(p.Missing) == 0 && len(p.Extra) == 0 && len(p.Issues) == 0
+}
+
+// Report is the result of verifying a project
+type Report struct {
+	OK        bool            `json:"ok"`
+	Packages  []PackageReport `json:"packages"`
+	Untracked []string        `json:"untracked"` // files in the install directory no package installed
+	Issues    []string        `json:"issues"`
+}
+
+// Run compares the installed files of a project with the hashes in its
+// lock file and checks that the manifest and lock file agree
+func Run(projectDir string, m *manifest.Manifest, lock *lockfile.LockFile) (*Report, error) {
+	report := &Report{Packages: []PackageReport{}, Untracked: []string{}, Issues: []string{}}
+
+	// Every package of the manifest and the lock file
+	var lockOnly []string
+	for name := range lock.Packages {
+		if _, ok := m.Dependencies[name]; !ok {
+			lockOnly = append(lockOnly, name)
+		}
+	}
+	sort.Strings(lockOnly)
+	names := append(m.DependencyNames(), lockOnly...)
+
+	reported := make(map[string]bool)
+	for _, name := range names {
+		pkgReport := PackageReport{
+			Name:     name,
+			Modified: []string{},
+			Missing:  []string{},
+			Extra:    []string{},
+			Issues:   []string{},
+		}
+
+		version, inManifest := m.Dependencies[name]
+		locked := lock.Package(name)
+		switch {
+		case locked == nil:
+			pkgReport.Issues = append(pkgReport.Issues, "not installed: missing from skillmaster.lock")
+		case !inManifest && !locked.Transitive:
+			pkgReport.Issues = append(pkgReport.Issues, "not listed in skillmaster.json")
+		case !inManifest && len(lock.Dependents(name)) == 0:
+			pkgReport.Issues = append(pkgReport.Issues, "installed as a dependency but no package requires it")
+		case inManifest && !resolver.Satisfies(version, locked.Version):
+			pkgReport.Issues = append(pkgReport.Issues, fmt.Sprintf("skillmaster.json requires %s but skillmaster.lock has %s", version, locked.Version))
+		}
+
+		// Dependencies declared by the installed version
+		if locked != nil {
+			deps := make([]string, 0, len(locked.Dependencies))
+			for dep := range locked.Dependencies {
+				deps = append(deps, dep)
+			}
+			sort.Strings(deps)
+			for _, dep := range deps {
+				constraint := locked.Dependencies[dep]
+				switch lockedDep := lock.Package(dep); {
+				case lockedDep == nil:
+					pkgReport.Issues = append(pkgReport.Issues, fmt.Sprintf("requires %s %s, which is not installed", dep, constraint))
+				case !resolver.Satisfies(constraint, lockedDep.Version):
+					pkgReport.Issues = append(pkgReport.Issues, fmt.Sprintf("requires %s %s but %s is installed", dep, constraint, lockedDep.Version))
+				}
+			}
+		}
+
+		if locked != nil {
+			pkgReport.Version = locked.Version
+			pkgReport.Files = len(locked.Files)
+
+			namespace := name
+			if owner, repo, err := github.ParseRepoURL(name); err == nil {
+				namespace = fmt.Sprintf("%s-%s", owner, repo)
+			}
+			changes, err := installer.DetectChanges(projectDir, namespace, lock, locked.Files)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range changes {
+				switch change.Kind {
+				case installer.ChangeModified:
+					pkgReport.Modified = append(pkgReport.Modified, change.Path)
+				case installer.ChangeDeleted:
+					pkgReport.Missing = append(pkgReport.Missing, change.Path)
+				case installer.ChangeAdded:
+					pkgReport.Extra = append(pkgReport.Extra, change.Path)
+					reported[change.Path] = true
+				}
+			}
+			for _, file := range locked.Files {
+				if file.Hash == "" {
+					pkgReport.Unverified = append(pkgReport.Unverified, file.Path)
+				}
+			}
+
+			// Overrides and patches the installation was built from
+			for _, override := range locked.Overrides {
+				if _, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(override.Path))); err != nil {
+					pkgReport.Issues = append(pkgReport.Issues, fmt.Sprintf("override %s is missing", override.Path))
+				}
+			}
+		}
+		for _, patchPath := range m.DependencyConfig[name].Patches {
+			if _, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(patchPath))); err != nil {
+				pkgReport.Issues = append(pkgReport.Issues, fmt.Sprintf("patch %s is missing", patchPath))
+			}
+		}
+
+		report.Packages = append(report.Packages, pkgReport)
+	}
+
+	// Files claimed by several packages
+	for _, filePath := range ownedTwice(lock) {
+		report.Issues = append(report.Issues, fmt.Sprintf("%s is recorded for several packages: %s", filePath, strings.Join(lock.Owner(filePath), ", ")))
+	}
+
+	// Files in the install directory that no package installed
+	untracked, err := untrackedFiles(projectDir, m.Config.InstallDir, lock)
+	if err != nil {
+		return nil, err
+	}
+	for _, filePath := range untracked {
+		if !reported[filePath] {
+			report.Untracked = append(report.Untracked, filePath)
+		}
+	}
+
+	report.OK = len(report.Untracked) == 0 && len(report.Issues) == 0
+	for i := range report.Packages {
+		if !report.Packages[i].OK() {
+			report.OK = false
+		}
+	}
+	return report, nil
+}
+
+// ownedTwice returns the paths of whole files recorded for more than one package
+func ownedTwice(lock *lockfile.LockFile) []string {
+	owners := make(map[string]map[string]bool)
+	for name, pkg := range lock.Packages {
+		for _, file := range pkg.Files {
+			if file.Section != "" {
+				continue
+			}
+			if owners[file.Path] == nil {
+				owners[file.Path] = make(map[string]bool)
+			}
+			owners[file.Path][name] = true
+		}
+	}
+
+	var paths []string
+	for filePath, names := range owners {
+		if len(names) > 1 {
+			paths = append(paths, filePath)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// untrackedFiles returns the files below installDir the lock file does not know
+func untrackedFiles(projectDir, installDir string, lock *lockfile.LockFile) ([]string, error) {
+	tracked := make(map[string]bool)
+	for _, pkg := range lock.Packages {
+		for _, file := range pkg.Files {
+			tracked[file.Path] = true
+		}
+	}
+
+	// The project root is not a dedicated install directory
+	var untracked []string
+	if filepath.Clean(installDir) == "." {
+		return nil, nil
+	}
+	root := filepath.Join(projectDir, installDir)
+	err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, filePath)
+		if err != nil {
+			return err
+		}
+		if rel = filepath.ToSlash(rel); !tracked[rel] {
+			untracked = append(untracked, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", installDir, err)
+	}
+	return untracked, nil
+}
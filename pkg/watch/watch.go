@@ -0,0 +1,165 @@
+package watch
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Defaults for Watcher
+const (
+	DefaultInterval = 500 * time.Millisecond
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// fileState is what a snapshot compares to detect changes
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot records the size and modification time of the files below a
+// directory, keyed by slash-separated relative path
+type Snapshot map[string]fileState
+
+// Scan takes a snapshot of dir. Hidden files and directories and editor
+// backup files are ignored.
+func Scan(dir string) (Snapshot, error) {
+	snapshot := make(Snapshot)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if p != dir && (strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~")) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			// Removed while scanning; the next scan sees it gone
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(rel)] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Diff returns the paths added, removed or modified in next, sorted
+func (s Snapshot) Diff(next Snapshot) []string {
+	var changed []string
+	for p, state := range next {
+		if old, ok := s[p]; !ok || old != state {
+			changed = append(changed, p)
+		}
+	}
+	for p := range s {
+		if _, ok := next[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Change lists the files that changed in a watched directory
+type Change struct {
+	Dir   string
+	Files []string
+}
+
+// Watcher polls directories for changes
+type Watcher struct {
+	Dirs []string
+	// Interval is the time between scans
+	Interval time.Duration
+	// Debounce is how long a directory has to stay unchanged before its
+	// changes are reported, so that a burst of saves is reported once
+	Debounce time.Duration
+}
+
+// Run polls the directories until stop is closed and calls onChange with
+// the changes of each burst once it settled. Directories that cannot be
+// read, for example while they are being replaced, are retried on the next
+// scan.
+func (w *Watcher) Run(stop <-chan struct{}, onChange func([]Change)) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	debounce := w.Debounce
+	if debounce < 0 {
+		debounce = 0
+	}
+
+	snapshots := make(map[string]Snapshot)
+	for _, dir := range w.Dirs {
+		if snapshot, err := Scan(dir); err == nil {
+			snapshots[dir] = snapshot
+		}
+	}
+
+	pending := make(map[string]map[string]bool)
+	var lastChange time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			for _, dir := range w.Dirs {
+				snapshot, err := Scan(dir)
+				if err != nil {
+					continue
+				}
+				changed := snapshots[dir].Diff(snapshot)
+				snapshots[dir] = snapshot
+				if len(changed) == 0 {
+					continue
+				}
+				if pending[dir] == nil {
+					pending[dir] = make(map[string]bool)
+				}
+				for _, p := range changed {
+					pending[dir][p] = true
+				}
+				lastChange = now
+			}
+
+			if len(pending) == 0 || now.Sub(lastChange) < debounce {
+				continue
+			}
+			var changes []Change
+			for _, dir := range w.Dirs {
+				if files, ok := pending[dir]; ok {
+					change := Change{Dir: dir}
+					for p := range files {
+						change.Files = append(change.Files, p)
+					}
+					sort.Strings(change.Files)
+					changes = append(changes, change)
+				}
+			}
+			pending = make(map[string]map[string]bool)
+			onChange(changes)
+		}
+	}
+}